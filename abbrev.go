@@ -0,0 +1,40 @@
+package gocommander
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resolveAbbreviation returns the candidate abbreviated by name.
+// If name is one of candidates, then this function returns name even if it abbreviates other candidates.
+// Otherwise, this function returns the empty string and the sorted candidates starting with name unless exactly one candidate starts with name.
+func resolveAbbreviation(name string, candidates []string) (string, []string) {
+	matches := []string{}
+	for _, candidate := range candidates {
+		if candidate == name {
+			return name, nil
+		}
+		if strings.HasPrefix(candidate, name) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	sort.Strings(matches)
+	return "", matches
+}
+
+// joinOr returns the string joining the given names like "a, b or c".
+func joinOr(names []string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// ambiguousError returns the error reporting that name of the given kind abbreviates all the matches.
+func ambiguousError(kind, name string, matches []string) error {
+	return fmt.Errorf("ambiguous %s: %s (did you mean %s?)", kind, name, joinOr(matches))
+}
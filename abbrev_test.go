@@ -0,0 +1,67 @@
+package gocommander
+
+import (
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+type preprocessCommand struct{}
+
+func (cmd *preprocessCommand) Description() string {
+	return "preprocess command"
+}
+
+func (cmd *preprocessCommand) Init(ctx *Context) {
+	ctx.AddOption("normalize-whitespace", NewOptionBool(false), "normalize whitespaces")
+	ctx.AddOption("normalize-case", NewOptionBool(false), "normalize cases")
+	ctx.AddOption("norm", NewOptionString(""), "norm")
+	ctx.AddOption("threads", NewOptionInt(1), "threads")
+}
+
+func (cmd *preprocessCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func TestResolveAbbreviation(t *testing.T) {
+	candidates := []string{"process", "preprocess", "prepare", "pre"}
+	goassert.New(t, "preprocess", []string(nil)).Equal(resolveAbbreviation("prepr", candidates[:3]))
+	goassert.New(t, "pre", []string(nil)).Equal(resolveAbbreviation("pre", candidates))
+	goassert.New(t, "", []string{"prepare", "preprocess"}).Equal(resolveAbbreviation("prep", candidates))
+	goassert.New(t, "", []string{}).Equal(resolveAbbreviation("x", candidates))
+	goassert.New(t, "").Equal(joinOr(nil))
+	goassert.New(t, "a").Equal(joinOr([]string{"a"}))
+	goassert.New(t, "a or b").Equal(joinOr([]string{"a", "b"}))
+	goassert.New(t, "a, b or c").Equal(joinOr([]string{"a", "b", "c"}))
+}
+
+func TestAbbreviation(t *testing.T) {
+	commander := New(&Settings{Abbreviation: true, ConfigCommand: true})
+	commander.Add("preprocess", &preprocessCommand{})
+	commander.Add("prepare", &command2{})
+	goassert.New(t, 4).EqualWithoutError(commander.Parse([]string{"@prepr", "normalize-w+", "norm=x", "th=4"}))
+	goassert.New(t, `[norm:"x" normalize-case:false normalize-whitespace:true threads:4]`).Equal(commander.Get("preprocess").OptionsString())
+	goassert.New(t, 2).EqualWithoutError(commander.Parse([]string{"@h", "@conf"}))
+	goassert.New(t, `ambiguous command: @pre \(did you mean @prepare or @preprocess\?\)`).ExpectError(commander.Parse([]string{"@pre"}))
+	goassert.New(t, `@preprocess: ambiguous option: normalize \(did you mean normalize-case or normalize-whitespace\?\)`).ExpectError(commander.Parse([]string{"@preprocess", "normalize+"}))
+	goassert.New(t, `@preprocess: unknown option: x \(valid options: help, norm, normalize-case, normalize-whitespace, threads\)`).ExpectError(commander.Parse([]string{"@preprocess", "x"}))
+	goassert.New(t, `unknown command: @x \(valid commands: @help, @config, @prepare, @preprocess\)`).ExpectError(commander.Parse([]string{"@x"}))
+
+	commander = New(nil)
+	commander.Add("preprocess", &preprocessCommand{})
+	goassert.New(t, `unknown command: @prep \(valid commands: @help, @preprocess\)`).ExpectError(commander.Parse([]string{"@prep"}))
+	goassert.New(t, `@preprocess: unknown option: th \(valid options: help, norm, normalize-case, normalize-whitespace, threads\)`).ExpectError(commander.Parse([]string{"@preprocess", "th=4"}))
+}
+
+func TestAbbreviationArgument(t *testing.T) {
+	commander := New(&Settings{Abbreviation: true})
+	commander.Add("convert", &convertCommand{})
+	commander.Add("preprocess", &preprocessCommand{})
+	goassert.New(t, 4).EqualWithoutError(commander.Parse([]string{"@conv", "in.csv", "out.json", "p"}))
+	goassert.New(t, `[fields:["p"] in:"in.csv" out:"out.json" pretty:false]`).Equal(commander.Get("convert").OptionsString())
+	goassert.New(t, 3).EqualWithoutError(commander.Parse([]string{"@conv", "i", "pre+"}))
+	goassert.New(t, `[fields:[] in:"i" out:"out.json" pretty:true]`).Equal(commander.Get("convert").OptionsString())
+	goassert.New(t, 2).EqualWithoutError(commander.Parse([]string{"@preprocess", "hel"}))
+	goassert.New(t, true).Equal(commander.Get("preprocess").help)
+	goassert.New(t, `@preprocess: unknown option: hel \(did you mean help\? valid options: help, norm, normalize-case, normalize-whitespace, threads\)`).ExpectError(commander.Parse([]string{"@preprocess", "hel+"}))
+}
@@ -0,0 +1,95 @@
+package gocommander
+
+import (
+	"fmt"
+	"strings"
+)
+
+// appender is the option appending a single value verbatim like OptionStringList.
+type appender interface {
+	Append(value string) error
+}
+
+// Variadic returns the OptionAttribute making the positional argument take all the remaining positional arguments.
+// The option should accumulate the values like OptionStringList.
+// Context.AddOption calls panic with this attribute.
+func Variadic() OptionAttribute {
+	return func(meta *optionMeta) {
+		meta.variadic = true
+	}
+}
+
+// AddArgument adds the given opt as the positional argument with the given name and attributes.
+// The positional arguments are assigned in the order of addition, and they can be also specified in the same form as options.
+// A positional argument is appended verbatim if the option has the method Append(value string) error like OptionStringList (thus, not split by ListSeparator), otherwise it is set as "=$ARG" (or parsed by strconv.ParseBool for OptionBool).
+// The positional argument is optional unless Required is given, and only the last one can be Variadic.
+//
+// This function calls panic if the argument follows a variadic one, a required argument follows an optional one, or AddOption calls panic.
+func (ctx *Context) AddArgument(name string, opt Option, description string, attrs ...OptionAttribute) {
+	meta := &optionMeta{}
+	for _, attr := range attrs {
+		attr(meta)
+	}
+	if n := len(ctx.arguments); n > 0 {
+		last := ctx.metas[ctx.arguments[n-1]]
+		if last.variadic {
+			panic(fmt.Errorf("argument %s cannot follow variadic argument %s", name, ctx.arguments[n-1]))
+		}
+		if meta.required && !last.required {
+			panic(fmt.Errorf("required argument %s cannot follow optional argument %s", name, ctx.arguments[n-1]))
+		}
+	}
+	ctx.addOption(name, opt, description, meta)
+	ctx.arguments = append(ctx.arguments, name)
+}
+
+// parseArgument sets the given command line argument to the first positional argument not specified yet, or the last variadic one.
+// Thus, the positional arguments specified as options are skipped.
+// If literal is false, then arg is reported as an unknown option if the command has no positional argument.
+//
+// This function returns an error if no positional argument is left or in setting the option.
+func (ctx *Context) parseArgument(arg string, literal bool) error {
+	if len(ctx.arguments) == 0 && !literal {
+		return ctx.unknownOptionError(arg)
+	}
+	index := 0
+	for index < len(ctx.arguments) && ctx.metas[ctx.arguments[index]].source.Kind != SourceDefault {
+		index++
+	}
+	if index >= len(ctx.arguments) {
+		index = len(ctx.arguments) - 1
+		if index < 0 || !ctx.metas[ctx.arguments[index]].variadic {
+			return fmt.Errorf("too many arguments: %s", arg)
+		}
+	}
+	name := ctx.arguments[index]
+	meta, opt, source := ctx.metas[name], ctx.opts[name], Source{Kind: SourceCommandLine}
+	var err error
+	if a, ok := opt.(appender); ok {
+		err = meta.setOptionBy(opt, "="+arg, source, func(str string) error {
+			return a.Append(str[len("="):])
+		})
+	} else {
+		err = meta.setValue(opt, arg, source)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+	return nil
+}
+
+// synopsis returns the synopsis of the positional arguments like "IN [OUT] [FILES...]".
+func (ctx *Context) synopsis() string {
+	parts := make([]string, 0, len(ctx.arguments))
+	for _, name := range ctx.arguments {
+		meta, part := ctx.metas[name], name
+		if meta.variadic {
+			part += "..."
+		}
+		if !meta.required {
+			part = "[" + part + "]"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
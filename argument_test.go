@@ -0,0 +1,124 @@
+package gocommander
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+	"github.com/hiro4bbh/go-log"
+)
+
+type convertCommand struct{}
+
+func (cmd *convertCommand) Description() string {
+	return "convert command"
+}
+
+func (cmd *convertCommand) Init(ctx *Context) {
+	ctx.AddArgument("in", NewOptionFilePath("", false, FilePathAny), "input file", Required())
+	ctx.AddArgument("out", NewOptionFilePath("out.json", false, FilePathAny), "output file")
+	ctx.AddArgument("fields", NewOptionStringList(nil), "fields", Variadic())
+	ctx.AddOption("pretty", NewOptionBool(false), "pretty print")
+}
+
+func (cmd *convertCommand) Run(ctx *Context) error {
+	return nil
+}
+
+type listCommand struct {
+	In string `option:"in" desc:"input" argument:"true" required:"true"`
+	N  int    `option:"n" desc:"count" argument:"true"`
+}
+
+func (cmd *listCommand) Description() string {
+	return "list command"
+}
+
+func (cmd *listCommand) Init(ctx *Context) {
+	ctx.Bind(cmd)
+}
+
+func (cmd *listCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func TestArgument(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "})})
+	commander.Add("convert", &convertCommand{})
+	goassert.New(t, 3).EqualWithoutError(commander.Parse([]string{"@convert", "pretty+", "in.csv"}))
+	ctx := commander.Get("convert")
+	goassert.New(t, FilePath("in.csv")).EqualWithoutError(Lookup[FilePath](ctx, "in"))
+	goassert.New(t, FilePath("out.json")).EqualWithoutError(Lookup[FilePath](ctx, "out"))
+	goassert.New(t, false).Equal(ctx.IsSet("out"))
+	goassert.New(t, true).EqualWithoutError(Lookup[bool](ctx, "pretty"))
+	goassert.New(t, 6).EqualWithoutError(commander.Parse([]string{"@convert", "in.csv", "pretty+", `\a=b.json`, "id", "name"}))
+	ctx = commander.Get("convert")
+	goassert.New(t, `[fields:["id" "name"] in:"in.csv" out:"a=b.json" pretty:true]`).Equal(ctx.OptionsString())
+	goassert.New(t, 3).EqualWithoutError(commander.Parse([]string{"@convert", "out=x.json", "in=y.csv"}))
+	goassert.New(t, 6).EqualWithoutError(commander.Parse([]string{"@convert", "in.csv", "out.json", "a,b.txt", "c", "fields=d,e"}))
+	goassert.New(t, `[fields:["a,b.txt" "c" "d" "e"] in:"in.csv" out:"out.json" pretty:false]`).Equal(commander.Get("convert").OptionsString())
+	goassert.New(t, 3).EqualWithoutError(commander.Parse([]string{"@convert", "in=a.csv", "b.json"}))
+	goassert.New(t, `[fields:[] in:"a.csv" out:"b.json" pretty:false]`).Equal(commander.Get("convert").OptionsString())
+	goassert.New(t, 5).EqualWithoutError(commander.Parse([]string{"@convert", "out=b.json", "a.csv", "id", "fields=name"}))
+	goassert.New(t, `[fields:["id" "name"] in:"a.csv" out:"b.json" pretty:false]`).Equal(commander.Get("convert").OptionsString())
+	goassert.New(t, 4).EqualWithoutError(commander.Parse([]string{"@convert", "fields=id", "a.csv", "name"}))
+	goassert.New(t, `[fields:["id"] in:"a.csv" out:"name" pretty:false]`).Equal(commander.Get("convert").OptionsString())
+	goassert.New(t, `@convert: unknown option: prety \(did you mean pretty\? valid options: help, fields, in, out, pretty\)`).ExpectError(commander.Parse([]string{"@convert", "in.csv", "prety+"}))
+	goassert.New(t, `@convert: unknown option: outt \(did you mean out\? valid options: help, fields, in, out, pretty\)`).ExpectError(commander.Parse([]string{"@convert", "in.csv", "outt=b.json"}))
+	goassert.New(t, `@convert: missing required arguments: in`).ExpectError(commander.Parse([]string{"@convert", "pretty+"}))
+
+	commander = New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "})})
+	commander.Add("cmd", &requiredCommand{})
+	goassert.New(t, `@cmd: unknown option: in.csv \(valid options: help, input, output, threads\)`).ExpectError(commander.Parse([]string{"@cmd", "in.csv"}))
+
+	commander.Add("list", &listCommand{})
+	goassert.New(t, `@list: too many arguments: c`).ExpectError(commander.Parse([]string{"@list", "a", "1", "c"}))
+	goassert.New(t, `@list: n: illegal OptionInt value: =b`).ExpectError(commander.Parse([]string{"@list", "a", "b"}))
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@list", "a", "2"}))
+	goassert.New(t, `[in:"a" n:2]`).Equal(commander.Get("list").OptionsString())
+}
+
+func TestArgumentEscape(t *testing.T) {
+	commander := New(nil)
+	commander.Add("convert", &convertCommand{})
+	commander.Add("list", &listCommand{})
+	goassert.New(t, 8).EqualWithoutError(commander.Parse([]string{"@convert", `\@in.csv`, `\help`, `\pretty+`, `\\x`, "--", "@list", "a"}))
+	ctx := commander.Get("convert")
+	goassert.New(t, `[fields:["pretty+" "\\x"] in:"@in.csv" out:"help" pretty:false]`).Equal(ctx.OptionsString())
+	goassert.New(t, []string{"@list", "a"}).Equal(ctx.Args())
+	goassert.New(t, false).Equal(commander.Get("list").IsSet("in"))
+}
+
+func TestArgumentHelp(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "})})
+	commander.Add("convert", &convertCommand{})
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@convert", "help"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\n@convert in [out] [fields...]: convert command\narguments:\n  in=PATH\tinput file (required)\n  out=PATH\toutput file (default \"out.json\")\n  fields=VALUE,...\tfields\noptions:\n  help\tShow this help and exit\n  pretty[+-]\tpretty print\n").Equal(buf.String())
+}
+
+func TestAddArgumentErrors(t *testing.T) {
+	ctx := newContext(New(nil), nil)
+	var caughtPanic interface{}
+	add := func(fn func()) {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		fn()
+	}
+	add(func() { ctx.AddArgument("a", NewOptionString(""), "") })
+	goassert.New(t, nil).Equal(caughtPanic)
+	add(func() { ctx.AddArgument("b", NewOptionString(""), "", Required()) })
+	goassert.New(t, fmt.Errorf("required argument b cannot follow optional argument a")).Equal(caughtPanic)
+	add(func() { ctx.AddArgument("c", NewOptionStringList(nil), "", Variadic()) })
+	goassert.New(t, nil).Equal(caughtPanic)
+	add(func() { ctx.AddArgument("d", NewOptionString(""), "") })
+	goassert.New(t, fmt.Errorf("argument d cannot follow variadic argument c")).Equal(caughtPanic)
+	add(func() { ctx.AddOption("e", NewOptionStringList(nil), "", Variadic()) })
+	goassert.New(t, fmt.Errorf("option e cannot be variadic")).Equal(caughtPanic)
+	add(func() { ctx.AddOption("a", NewOptionString(""), "") })
+	goassert.New(t, fmt.Errorf("option name a is already used")).Equal(caughtPanic)
+}
@@ -0,0 +1,121 @@
+package gocommander
+
+import (
+	"fmt"
+	"strings"
+)
+
+// optionMeta is the metadata of an option in a Context.
+type optionMeta struct {
+	// required is true if the option must be specified.
+	required bool
+	// source is the source of the option value, which is SourceDefault if the option is not specified.
+	source Source
+	// normalizers are applied to the value in order before setting it.
+	normalizers []func(value string) string
+	// validators are applied to the option in order after setting the value.
+	validators []func(opt Option) error
+	// env is the environment variable name used if the option is not specified.
+	env string
+	// secret is true if the value must not be shown.
+	secret bool
+	// variadic is true if the positional argument takes all the remaining positional arguments.
+	variadic bool
+}
+
+// OptionAttribute is an attribute given to Context.AddOption.
+type OptionAttribute func(meta *optionMeta)
+
+// Required returns the OptionAttribute making the option required.
+// Commander.Parse reports the required options not specified.
+func Required() OptionAttribute {
+	return func(meta *optionMeta) {
+		meta.required = true
+	}
+}
+
+// EnvVar returns the OptionAttribute making the option fall back on the environment variable with the given name.
+// This overrides the environment variable name derived from Settings.EnvPrefix.
+func EnvVar(name string) OptionAttribute {
+	return func(meta *optionMeta) {
+		meta.env = name
+	}
+}
+
+// Normalizer returns the OptionAttribute normalizing VALUE of "=$VALUE" by fn before setting it to the option.
+// The normalizers are applied in order.
+func Normalizer(fn func(value string) string) OptionAttribute {
+	return func(meta *optionMeta) {
+		meta.normalizers = append(meta.normalizers, fn)
+	}
+}
+
+// Validator returns the OptionAttribute validating the option by fn after setting the value.
+// fn should return an error if the value is invalid.
+// The validators are applied in order.
+// A rejected value is not rolled back, because Context.Parse and Commander.Parse fail on it.
+func Validator(fn func(opt Option) error) OptionAttribute {
+	return func(meta *optionMeta) {
+		meta.validators = append(meta.validators, fn)
+	}
+}
+
+// ValidatorOf returns Validator validating the value of the type T returned by the Get method of the option.
+func ValidatorOf[T any](fn func(value T) error) OptionAttribute {
+	return Validator(func(opt Option) error {
+		getter, ok := opt.(interface{ Get() T })
+		if !ok {
+			return fmt.Errorf("%T does not have a value of type %s", opt, typeName[T]())
+		}
+		return fn(getter.Get())
+	})
+}
+
+// setOption sets the value string str (in the form of Option.Set) from the given source to the option with the meta.
+func (meta *optionMeta) setOption(opt Option, str string, source Source) error {
+	return meta.setOptionBy(opt, str, source, opt.Set)
+}
+
+// setOptionBy sets the value string str like setOption, but uses set instead of opt.Set.
+func (meta *optionMeta) setOptionBy(opt Option, str string, source Source, set func(str string) error) error {
+	if strings.HasPrefix(str, "=") {
+		value := str[len("="):]
+		for _, normalize := range meta.normalizers {
+			value = normalize(value)
+		}
+		str = "=" + value
+	}
+	if err := meta.maskError(set(str), str); err != nil {
+		return err
+	}
+	for _, validate := range meta.validators {
+		if err := meta.maskError(validate(opt), str); err != nil {
+			return err
+		}
+	}
+	meta.source = source
+	return nil
+}
+
+// setValue sets the plain value string (converted by setString) from the given source to the option with the meta.
+func (meta *optionMeta) setValue(opt Option, value string, source Source) error {
+	str, err := setString(opt, value)
+	if err != nil {
+		return meta.maskError(err, value)
+	}
+	return meta.setOption(opt, str, source)
+}
+
+// maskError returns err whose message has str and its value part masked if the option is secret.
+func (meta *optionMeta) maskError(err error, str string) error {
+	if err == nil || !meta.secret {
+		return err
+	}
+	msg := err.Error()
+	for _, s := range []string{str, strings.TrimPrefix(str, "=")} {
+		if len(s) > 1 {
+			msg = strings.ReplaceAll(msg, s, SecretMask)
+		}
+	}
+	return fmt.Errorf("%s", msg)
+}
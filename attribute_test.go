@@ -0,0 +1,132 @@
+package gocommander
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+	"github.com/hiro4bbh/go-log"
+)
+
+type requiredCommand struct{}
+
+func (cmd *requiredCommand) Description() string {
+	return "required command"
+}
+
+func (cmd *requiredCommand) Init(ctx *Context) {
+	ctx.AddOption("input", NewOptionString(""), "input", Required())
+	ctx.AddOption("output", NewOptionString(""), "output", Required())
+	ctx.AddOption("threads", NewOptionInt(4), "threads")
+}
+
+func (cmd *requiredCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func TestRequired(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "})})
+	ctx := commander.Add("cmd", &requiredCommand{})
+	goassert.New(t, false).Equal(ctx.IsSet("input"))
+	goassert.New(t, `@cmd: missing required options: input, output`).ExpectError(commander.Parse([]string{"@cmd", "threads=4"}))
+	goassert.New(t, `@cmd: missing required options: output`).ExpectError(commander.Parse([]string{"@cmd", "input=a"}))
+	goassert.New(t, 4).Equal(goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "input=a", "output=", "threads=4"})).(int))
+	ctx = commander.Get("cmd")
+	goassert.New(t, true).Equal(ctx.IsSet("input"))
+	goassert.New(t, true).Equal(ctx.IsSet("output"))
+	goassert.New(t, true).Equal(ctx.IsSet("threads"))
+	goassert.New(t, false).Equal(ctx.IsSet("unknown"))
+	goassert.New(t, 2).Equal(goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "help"})).(int))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\n@cmd: required command\noptions:\n  help\tShow this help and exit\n  input=VALUE\tinput (required)\n  output=VALUE\toutput (required)\n  threads=INT\tthreads (default 4)\n").Equal(buf.String())
+	goassert.New(t, false).Equal(commander.Get("cmd").IsSet("threads"))
+}
+
+func TestValidatorAndNormalizer(t *testing.T) {
+	ctx := newContext(New(nil), nil)
+	ctx.AddOption("port", NewOptionInt(8080), "port", ValidatorOf(func(port int) error {
+		if port < 1024 || port > 65535 {
+			return fmt.Errorf("port must be in [1024, 65535]")
+		}
+		return nil
+	}))
+	ctx.AddOption("input", NewOptionFilePath("", false, FilePathAny), "input", Normalizer(strings.TrimSpace), ValidatorOf(func(path FilePath) error {
+		if path.Ext() != ".csv" {
+			return fmt.Errorf("extension must be .csv")
+		}
+		return nil
+	}))
+	ctx.AddOption("name", NewOptionString(""), "name", Normalizer(strings.TrimSpace), Normalizer(strings.ToLower), Validator(func(opt Option) error {
+		if !regexp.MustCompile(`^[a-z]+$`).MatchString(opt.(*OptionString).Get()) {
+			return fmt.Errorf("name must match ^[a-z]+$")
+		}
+		return nil
+	}))
+	ctx.AddOption("mistyped", NewOptionBool(false), "mistyped", ValidatorOf(func(value string) error {
+		return nil
+	}))
+	goassert.New(t, 3).Equal(goassert.New(t).SucceedNew(ctx.Parse([]string{"port=8000", "input= data.csv ", "name= Alice "})).(int))
+	goassert.New(t, 8000).Equal(ctx.GetOption("port").(*OptionInt).Get())
+	goassert.New(t, FilePath("data.csv")).Equal(ctx.GetOption("input").(*OptionFilePath).Get())
+	goassert.New(t, "alice").Equal(ctx.GetOption("name").(*OptionString).Get())
+	goassert.New(t, `port: port must be in \[1024, 65535\]`).ExpectError(ctx.Parse([]string{"port=80"}))
+	goassert.New(t, `port: illegal OptionInt value: =x`).ExpectError(ctx.Parse([]string{"port=x"}))
+	goassert.New(t, `input: extension must be .csv`).ExpectError(ctx.Parse([]string{"input=data.json"}))
+	goassert.New(t, `name: name must match \^\[a-z\]\+\$`).ExpectError(ctx.Parse([]string{"name=Alice1"}))
+	goassert.New(t, `mistyped: \*gocommander.OptionBool does not have a value of type string`).ExpectError(ctx.Parse([]string{"mistyped"}))
+}
+
+type envCommand struct {
+	Threads int    `option:"threads" desc:"threads" default:"4"`
+	Debug   bool   `option:"debug" desc:"debug mode"`
+	Token   string `option:"token" desc:"token" env:"TEST_TOKEN" required:"true"`
+}
+
+func (cmd *envCommand) Description() string {
+	return "env command"
+}
+
+func (cmd *envCommand) Init(ctx *Context) {
+	ctx.Bind(cmd)
+	ctx.AddOption("learning-rate", NewOptionFloat(0.1), "learning rate")
+}
+
+func (cmd *envCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func TestEnvVar(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{
+		Logger:    golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "}),
+		EnvPrefix: "TEST_",
+	})
+	goassert.New(t, "TEST_").Equal(commander.EnvPrefix())
+	cmd := &envCommand{}
+	commander.Add("train-1", cmd)
+	t.Setenv("TEST_TRAIN_1_THREADS", "8")
+	t.Setenv("TEST_TRAIN_1_DEBUG", "true")
+	t.Setenv("TEST_TRAIN_1_LEARNING_RATE", "0.5")
+	t.Setenv("TEST_TOKEN", "secret")
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@train-1", "threads=2"}))
+	ctx := commander.Get("train-1")
+	goassert.New(t, 2).Equal(cmd.Threads)
+	goassert.New(t, true).Equal(cmd.Debug)
+	goassert.New(t, "secret").Equal(cmd.Token)
+	goassert.New(t, 0.5).Equal(ctx.GetOption("learning-rate").(*OptionFloat).Get())
+	goassert.New(t, true).Equal(ctx.IsSet("debug"))
+
+	t.Setenv("TEST_TRAIN_1_DEBUG", "yes")
+	goassert.New(t, `@train-1: debug: \$TEST_TRAIN_1_DEBUG: illegal OptionBool value: yes`).ExpectError(commander.Parse([]string{"@train-1"}))
+	t.Setenv("TEST_TRAIN_1_DEBUG", "")
+	t.Setenv("TEST_TOKEN", "")
+	goassert.New(t, `@train-1: missing required options: token`).ExpectError(commander.Parse([]string{"@train-1"}))
+
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@train-1", "help"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\n@train-1: env command\noptions:\n  help\tShow this help and exit\n  debug[+-]\tdebug mode (env $TEST_TRAIN_1_DEBUG)\n  learning-rate=FLOAT\tlearning rate (default 0.1, env $TEST_TRAIN_1_LEARNING_RATE)\n  threads=INT\tthreads (default 4, env $TEST_TRAIN_1_THREADS)\n  token=VALUE\ttoken (required) (env $TEST_TOKEN)\n").Equal(buf.String())
+}
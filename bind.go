@@ -0,0 +1,164 @@
+package gocommander
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// binding is a struct field bound to an Option.
+type binding struct {
+	field reflect.Value
+	opt   Option
+}
+
+// populate sets the field to the option value.
+func (b binding) populate() {
+	b.field.Set(reflect.ValueOf(b.opt).MethodByName("Get").Call(nil)[0])
+}
+
+// setString returns the string in the form of Option.Set for the given plain value string (as in struct tags).
+// OptionBool accepts the values accepted by strconv.ParseBool, and the other options accept "=$VALUE".
+func setString(opt Option, value string) (string, error) {
+	if _, ok := opt.(*OptionBool); ok {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("illegal OptionBool value: %s", value)
+		}
+		if b {
+			return "+", nil
+		}
+		return "-", nil
+	}
+	return "=" + value, nil
+}
+
+var optionType = reflect.TypeOf((*Option)(nil)).Elem()
+
+// newOptionForType returns a new Option whose Get returns a value of the given type.
+// If choices is not empty, then a string type is bound to OptionEnum.
+func newOptionForType(typ reflect.Type, choices []string) Option {
+	switch typ {
+	case reflect.TypeOf(time.Duration(0)):
+		return NewOptionDuration(0)
+	case reflect.TypeOf(time.Time{}):
+		return NewOptionTime(time.Time{})
+	case reflect.TypeOf(FilePath("")):
+		return NewOptionFilePath("", false, FilePathAny)
+	case reflect.TypeOf((*url.URL)(nil)):
+		return NewOptionURL("")
+	case reflect.TypeOf(net.IP(nil)):
+		return NewOptionIP("")
+	case reflect.TypeOf((*net.IPNet)(nil)):
+		return NewOptionCIDR("")
+	case reflect.TypeOf((*regexp.Regexp)(nil)):
+		return NewOptionRegexp("")
+	case reflect.TypeOf([]string(nil)):
+		return NewOptionStringList(nil)
+	case reflect.TypeOf([]int(nil)):
+		return NewOptionIntList(nil)
+	case reflect.TypeOf(map[string]string(nil)):
+		return NewOptionMap(nil)
+	case reflect.TypeOf(false):
+		return NewOptionBool(false)
+	case reflect.TypeOf(""):
+		if len(choices) > 0 {
+			return NewOptionEnum("", choices, false)
+		}
+		return NewOptionString("")
+	case reflect.TypeOf(int(0)):
+		return NewOptionInt(0)
+	case reflect.TypeOf(uint(0)):
+		return NewOptionUint(0)
+	case reflect.TypeOf(float64(0)):
+		return NewOptionFloat(0)
+	}
+	return nil
+}
+
+// Bind adds the options for the fields of the struct pointed by ptr, and binds the fields to the options.
+// The bound fields are set to the default values immediately, and set to the parsed values by Commander.Parse.
+//
+// The fields are configured with the following struct tags:
+//
+//	option:"NAME"       the option name (the fields without this tag are ignored)
+//	desc:"DESCRIPTION"  the option description
+//	default:"VALUE"     the default value set as "=VALUE" (or parsed by strconv.ParseBool for bool)
+//	required:"true"     the option must be specified
+//	env:"NAME"          the environment variable used if the option is not specified
+//	secret:"true"       the option value is secret
+//	argument:"true"     the option is added as a positional argument by AddArgument
+//	variadic:"true"     the positional argument takes all the remaining positional arguments
+//	choices:"A,B,..."   the choices of a string field (bound to OptionEnum)
+//
+// The field types bool, string, int, uint, float64, time.Duration, time.Time, FilePath, *url.URL, net.IP, *net.IPNet, *regexp.Regexp, []string, []int and map[string]string are bound to the corresponding built-in Option.
+// The fields whose types implement Option are added as they are, so they must be initialized before calling this function.
+//
+// This function calls panic if ptr is not a pointer to a struct, a field type is unsupported, a default value is illegal, AddOption calls panic, or AddArgument calls panic.
+func (ctx *Context) Bind(ptr interface{}) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("cannot bind %T: not a pointer to a struct", ptr))
+	}
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field, sf := v.Field(i), v.Type().Field(i)
+		name, ok := sf.Tag.Lookup("option")
+		if !ok {
+			continue
+		}
+		if !field.CanSet() {
+			panic(fmt.Errorf("cannot bind field %s: unexported", sf.Name))
+		}
+		var choices []string
+		if str := sf.Tag.Get("choices"); str != "" {
+			choices = strings.Split(str, ListSeparator)
+		}
+		var opt Option
+		if sf.Type.Implements(optionType) {
+			if field.IsNil() {
+				panic(fmt.Errorf("cannot bind field %s: nil %s", sf.Name, sf.Type))
+			}
+			opt = field.Interface().(Option)
+		} else if opt = newOptionForType(sf.Type, choices); opt == nil {
+			panic(fmt.Errorf("cannot bind field %s: unsupported type %s", sf.Name, sf.Type))
+		}
+		if defval, ok := sf.Tag.Lookup("default"); ok {
+			str, err := setString(opt, defval)
+			if err == nil {
+				err = opt.Set(str)
+			}
+			if err != nil {
+				panic(fmt.Errorf("cannot bind field %s: illegal default value: %s", sf.Name, err))
+			}
+		}
+		var attrs []OptionAttribute
+		if required, _ := strconv.ParseBool(sf.Tag.Get("required")); required {
+			attrs = append(attrs, Required())
+		}
+		if env := sf.Tag.Get("env"); env != "" {
+			attrs = append(attrs, EnvVar(env))
+		}
+		if secret, _ := strconv.ParseBool(sf.Tag.Get("secret")); secret {
+			attrs = append(attrs, Secret())
+		}
+		if variadic, _ := strconv.ParseBool(sf.Tag.Get("variadic")); variadic {
+			attrs = append(attrs, Variadic())
+		}
+		if argument, _ := strconv.ParseBool(sf.Tag.Get("argument")); argument {
+			ctx.AddArgument(name, opt, sf.Tag.Get("desc"), attrs...)
+		} else {
+			ctx.AddOption(name, opt, sf.Tag.Get("desc"), attrs...)
+		}
+		if !sf.Type.Implements(optionType) {
+			b := binding{field, opt}
+			b.populate()
+			ctx.bindings = append(ctx.bindings, b)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package gocommander
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+type bindCommand struct {
+	Debug    bool              `option:"debug" desc:"debug mode"`
+	Name     string            `option:"name" desc:"name" required:"true"`
+	Format   string            `option:"format" desc:"format" choices:"json,csv" default:"json"`
+	Threads  int               `option:"threads" desc:"number of threads" default:"4"`
+	Retries  uint              `option:"retries" desc:"number of retries"`
+	Rate     float64           `option:"rate" desc:"learning rate" default:"0.1"`
+	Timeout  time.Duration     `option:"timeout" desc:"timeout" default:"30s"`
+	Since    time.Time         `option:"since" desc:"since"`
+	Output   FilePath          `option:"output" desc:"output"`
+	Endpoint *url.URL          `option:"endpoint" desc:"endpoint"`
+	Addr     net.IP            `option:"addr" desc:"address"`
+	Network  *net.IPNet        `option:"network" desc:"network"`
+	Filter   *regexp.Regexp    `option:"filter" desc:"filter"`
+	Include  []string          `option:"include" desc:"include"`
+	Shards   []int             `option:"shards" desc:"shards" default:"1,2"`
+	Labels   map[string]string `option:"label" desc:"label"`
+	Verbose  *OptionCounter    `option:"v" desc:"verbosity"`
+	Ignored  int
+}
+
+func (cmd *bindCommand) Description() string {
+	return "bind command"
+}
+
+func (cmd *bindCommand) Init(ctx *Context) {
+	cmd.Verbose = NewOptionCounter(0)
+	ctx.Bind(cmd)
+}
+
+func (cmd *bindCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func TestContextBind(t *testing.T) {
+	commander := New(nil)
+	cmd := &bindCommand{}
+	ctx := commander.Add("cmd", cmd)
+	goassert.New(t, "json").Equal(cmd.Format)
+	goassert.New(t, 4).Equal(cmd.Threads)
+	goassert.New(t, 30*time.Second).Equal(cmd.Timeout)
+	goassert.New(t, []int{1, 2}).Equal(cmd.Shards)
+	goassert.New(t, `illegal OptionEnum value \(expected one of json, csv\): =xml`).ExpectError(ctx.GetOption("format").Set("=xml"))
+	goassert.New(t, 20).Equal(goassert.New(t).SucceedNew(commander.Parse([]string{
+		"@cmd", "debug", "name=x", "format=csv", "threads=8", "retries=3", "rate=0.5", "timeout=1m",
+		"since=2018-01-02", "output=out.txt", "endpoint=http://example.com/", "addr=127.0.0.1", "network=10.0.0.0/8",
+		"filter=^a", "include=a,b", "shards-", "shards=3", "label=k:v", "v", "v",
+	})).(int))
+	goassert.New(t, true).Equal(cmd.Debug)
+	goassert.New(t, "x").Equal(cmd.Name)
+	goassert.New(t, "csv").Equal(cmd.Format)
+	goassert.New(t, 8).Equal(cmd.Threads)
+	goassert.New(t, uint(3)).Equal(cmd.Retries)
+	goassert.New(t, 0.5).Equal(cmd.Rate)
+	goassert.New(t, time.Minute).Equal(cmd.Timeout)
+	goassert.New(t, time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)).Equal(cmd.Since)
+	goassert.New(t, FilePath("out.txt")).Equal(cmd.Output)
+	goassert.New(t, "example.com").Equal(cmd.Endpoint.Host)
+	goassert.New(t, "127.0.0.1").Equal(cmd.Addr.String())
+	goassert.New(t, "10.0.0.0/8").Equal(cmd.Network.String())
+	goassert.New(t, "^a").Equal(cmd.Filter.String())
+	goassert.New(t, []string{"a", "b"}).Equal(cmd.Include)
+	goassert.New(t, []int{3}).Equal(cmd.Shards)
+	goassert.New(t, map[string]string{"k": "v"}).Equal(cmd.Labels)
+	goassert.New(t, 2).Equal(cmd.Verbose.Get())
+	goassert.New(t, 0).Equal(cmd.Ignored)
+
+	goassert.New(t, 2).Equal(goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "name=y"})).(int))
+	goassert.New(t, false).Equal(cmd.Debug)
+	goassert.New(t, "json").Equal(cmd.Format)
+	goassert.New(t, 0).Equal(cmd.Verbose.Get())
+	goassert.New(t, []int{1, 2}).Equal(cmd.Shards)
+
+	goassert.New(t, `@cmd: missing required options: name`).ExpectError(commander.Parse([]string{"@cmd", "debug"}))
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "help"}))
+}
+
+func TestContextBindErrors(t *testing.T) {
+	ctx := newContext(New(nil), nil)
+	var caughtPanic interface{}
+	bind := func(ptr interface{}) {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		ctx.Bind(ptr)
+	}
+	bind(struct{}{})
+	goassert.New(t, fmt.Errorf("cannot bind struct {}: not a pointer to a struct")).Equal(caughtPanic)
+	bind(&struct {
+		Value int8 `option:"value"`
+	}{})
+	goassert.New(t, fmt.Errorf("cannot bind field Value: unsupported type int8")).Equal(caughtPanic)
+	bind(&struct {
+		value int `option:"value"`
+	}{})
+	goassert.New(t, fmt.Errorf("cannot bind field value: unexported")).Equal(caughtPanic)
+	bind(&struct {
+		Value *OptionCounter `option:"value"`
+	}{})
+	goassert.New(t, fmt.Errorf("cannot bind field Value: nil *gocommander.OptionCounter")).Equal(caughtPanic)
+	bind(&struct {
+		Value bool `option:"value" default:"yes"`
+	}{})
+	goassert.New(t, fmt.Errorf("cannot bind field Value: illegal default value: illegal OptionBool value: yes")).Equal(caughtPanic)
+}
@@ -16,6 +16,21 @@ type Settings struct {
 	Copyright string
 	// Logger is the commander's logger.
 	Logger *golog.Logger
+	// EnvPrefix is the prefix of the environment variables which options fall back on.
+	// If EnvPrefix is not empty, then the option OPT of the command CMD falls back on the environment variable $EnvPrefix$CMD_$OPT, where CMD and OPT are converted to upper case and the non-alphanumeric characters are replaced with "_".
+	EnvPrefix string
+	// ConfigCommand enables the built-in command @config, which chooses the configuration file and the profile, and shows the effective options.
+	// If ConfigCommand is true, then the command name "config" is reserved.
+	ConfigCommand bool
+	// ConfigFile is the default configuration file path, which is expanded by FilePath.Expand.
+	// The file is loaded if it exists and @config does not specify another file.
+	ConfigFile FilePath
+	// ConfigProfileEnv is the environment variable name selecting the configuration profile if @config does not specify it.
+	// If ConfigProfileEnv is empty, then the profile falls back on $EnvPrefixCONFIG_PROFILE only if EnvPrefix is not empty.
+	ConfigProfileEnv string
+	// Abbreviation enables the abbreviation of the command names and the option names.
+	// If Abbreviation is true, then Commander.Parse and Context.Parse accept the unique prefix of a name as the name.
+	Abbreviation bool
 }
 
 var (
@@ -30,8 +45,12 @@ var (
 type Commander struct {
 	settings *Settings
 	ctxs     map[string]*Context
+	config   *Context
+	cfg      *configFile
 	help     bool
 	queue    []string
+	// configParsed is true if @config is parsed.
+	configParsed bool
 }
 
 // New returns a new Commander with the given CommanderSettings.
@@ -54,24 +73,29 @@ func New(settings *Settings) *Commander {
 	if settings.Logger == nil {
 		settings.Logger = golog.Null
 	}
-	return &Commander{
+	commander := &Commander{
 		settings: settings,
 		ctxs:     map[string]*Context{},
 	}
+	commander.config = newContext(commander, &configCommand{})
+	commander.config.name = "config"
+	commander.config.cmd.Init(commander.config)
+	return commander
 }
 
 // Add adds a new command with the given command name and command handlers, and returns its Context.
 //
 // This function calls panic if the given command name is used or the given command name is illegal.
-// The illegal command names are "h", "help", or one ending with "+" or "-" or "=".
+// The illegal command names are "h", "help", "config" (only if Settings.ConfigCommand is true), one starting with "@" (see ResponseFilePrefix), or one ending with "+" or "-" or "=".
 func (commander *Commander) Add(name string, cmd Command) *Context {
 	if _, ok := commander.ctxs[name]; ok {
 		panic(fmt.Errorf("commander has already command %s", name))
 	}
-	if name == "help" || strings.HasSuffix(name, "+") || strings.HasSuffix(name, "-") || strings.HasSuffix(name, "=") {
+	if name == "help" || (name == "config" && commander.settings.ConfigCommand) || strings.HasPrefix(name, "@") || strings.HasSuffix(name, "+") || strings.HasSuffix(name, "-") || strings.HasSuffix(name, "=") {
 		panic(fmt.Errorf("illegal command name: %s", name))
 	}
 	ctx := newContext(commander, cmd)
+	ctx.name = name
 	ctx.cmd.Init(ctx)
 	commander.ctxs[name] = ctx
 	return ctx
@@ -82,6 +106,16 @@ func (commander *Commander) Copyright() string {
 	return commander.settings.Copyright
 }
 
+// Abbreviation returns true if the commander accepts the abbreviated names.
+func (commander *Commander) Abbreviation() bool {
+	return commander.settings.Abbreviation
+}
+
+// EnvPrefix returns the commander's prefix of the environment variables.
+func (commander *Commander) EnvPrefix() string {
+	return commander.settings.EnvPrefix
+}
+
 // Get returns the Context with the given command name.
 func (commander *Commander) Get(name string) *Context {
 	return commander.ctxs[name]
@@ -101,26 +135,28 @@ func (commander *Commander) Name() string {
 func (commander *Commander) Help() {
 	w := commander.Logger().Writer()
 	fmt.Fprintf(w, "%s\n%s\n\ncommands:\n", commander.Name(), commander.Copyright())
-	names := make([]string, 0, len(commander.ctxs)+1)
-	names = append(names, "@help")
-	for name := range commander.ctxs {
-		names = append(names, name)
-	}
-	sort.Strings(names[1:])
-	for i, name := range names {
+	for i, name := range commander.commandNames() {
 		if i == 0 {
-			fmt.Fprintf(w, "  %s\tShow this help and exit\n", name)
+			fmt.Fprintf(w, "  @%s\tShow this help and exit\n", name)
+		} else if ctx := commander.ctxs[name]; ctx != nil {
+			fmt.Fprintf(w, "  @%s\t%s\n", name, ctx.cmd.Description())
 		} else {
-			fmt.Fprintf(w, "  @%s\t%s\n", name, commander.ctxs[name].cmd.Description())
+			fmt.Fprintf(w, "  @%s\t%s\n", name, commander.config.cmd.Description())
 		}
 	}
 }
 
 // Parse parses the given command line arguments, and returns the next argument index.
+// The response file arguments "@@$FILE" are expanded by ExpandResponseFiles before parsing, so the returned index is of the expanded arguments.
+// If Settings.Abbreviation is true, then the unique prefix of a command name is accepted as the command name.
 //
 // This function returns an error in parsing.
 func (commander *Commander) Parse(args []string) (int, error) {
 	commander.Reset()
+	args, err := ExpandResponseFiles(args)
+	if err != nil {
+		return -1, err
+	}
 	i := 0
 	for i < len(args) {
 		arg := args[i]
@@ -128,14 +164,42 @@ func (commander *Commander) Parse(args []string) (int, error) {
 			return -1, fmt.Errorf("expected command name, but got: %s", arg)
 		}
 		name := arg[1:]
+		if name != "" && commander.Abbreviation() {
+			resolved, matches := resolveAbbreviation(name, commander.commandNames())
+			if len(matches) > 1 {
+				for j := range matches {
+					matches[j] = "@" + matches[j]
+				}
+				return -1, ambiguousError("command", "@"+name, matches)
+			}
+			if resolved != "" {
+				name = resolved
+			}
+		}
 		if name == "help" {
 			commander.help = true
 			i++
 			continue
 		}
+		if name == "config" && commander.settings.ConfigCommand {
+			if commander.configParsed {
+				return -1, fmt.Errorf("cannot run @config multiple times")
+			}
+			j, err := commander.config.Parse(args[i+1:])
+			if err != nil {
+				return -1, fmt.Errorf("@config: %s", err)
+			}
+			commander.configParsed = true
+			i += j + 1
+			continue
+		}
 		ctx := commander.Get(name)
 		if ctx == nil {
-			return -1, fmt.Errorf("unknown command: @%s", name)
+			names := commander.commandNames()
+			for j := range names {
+				names[j] = "@" + names[j]
+			}
+			return -1, fmt.Errorf("unknown command: @%s%s", name, unknownSuffix("@"+name, names, "commands"))
 		}
 		found := false
 		for _, n := range commander.queue {
@@ -154,21 +218,71 @@ func (commander *Commander) Parse(args []string) (int, error) {
 		commander.queue = append(commander.queue, name)
 		i += j + 1
 	}
+	if !commander.helpRequested() {
+		if err := commander.loadConfig(); err != nil {
+			return -1, fmt.Errorf("@config: %s", err)
+		}
+		for _, name := range commander.queue {
+			if err := commander.ctxs[name].complete(); err != nil {
+				return -1, fmt.Errorf("@%s: %s", name, err)
+			}
+		}
+	}
 	return i, nil
 }
 
+// commandNames returns "help", "config" (only if Settings.ConfigCommand is true) and the sorted command names.
+func (commander *Commander) commandNames() []string {
+	builtins := []string{"help"}
+	if commander.settings.ConfigCommand {
+		builtins = append(builtins, "config")
+	}
+	return append(builtins, commander.userCommandNames()...)
+}
+
+// userCommandNames returns the sorted command names added by Add.
+func (commander *Commander) userCommandNames() []string {
+	names := make([]string, 0, len(commander.ctxs))
+	for name := range commander.ctxs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// helpRequested returns true if the help of the commander or a queued command is requested.
+func (commander *Commander) helpRequested() bool {
+	if commander.help || commander.config.help {
+		return true
+	}
+	for _, name := range commander.queue {
+		if commander.ctxs[name].help {
+			return true
+		}
+	}
+	return false
+}
+
 // Reset resets the commander and its command states.
 func (commander *Commander) Reset() {
 	for name, ctx := range commander.ctxs {
 		newctx := newContext(commander, ctx.cmd)
+		newctx.name = name
 		newctx.cmd.Init(newctx)
 		commander.ctxs[name] = newctx
 	}
+	commander.config = newContext(commander, commander.config.cmd)
+	commander.config.name = "config"
+	commander.config.cmd.Init(commander.config)
+	commander.cfg = nil
 	commander.help = false
+	commander.configParsed = false
 	commander.queue = []string{}
 }
 
 // Run runs the commands in order.
+// If the help is requested, then this function shows it instead.
+// If "@config show" is specified, then this function shows the effective options of the commands by ShowConfig instead.
 //
 // This function stops the execution as soon as a command returns and error, then returns it.
 func (commander *Commander) Run() error {
@@ -176,6 +290,14 @@ func (commander *Commander) Run() error {
 		commander.Help()
 		return nil
 	}
+	if commander.config.help {
+		commander.config.Help("config")
+		return nil
+	}
+	if commander.config.GetOption("show").(*OptionBool).Get() {
+		commander.ShowConfig()
+		return nil
+	}
 	for _, name := range commander.queue {
 		ctx := commander.ctxs[name]
 		if ctx.help {
@@ -81,6 +81,33 @@ func TestCommanderHelp(t *testing.T) {
 	goassert.New(t, " INFO   command1 is started\n").Equal(buf.String())
 }
 
+func TestCommanderConfigCommand(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "})})
+	cmd := &command1{}
+	commander.Add("config", cmd)
+	goassert.New(t, 2).EqualWithoutError(commander.Parse([]string{"@config", "opt1"}))
+	goassert.New(t, true).Equal(commander.Get("config").GetOption("opt1").(*OptionBool).Get())
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@help"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\ncommands:\n  @help\tShow this help and exit\n  @config\tcommand 1\n").Equal(buf.String())
+
+	buf.Reset()
+	commander = New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "}), ConfigCommand: true})
+	commander.Add("cmd1", cmd)
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@help"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\ncommands:\n  @help\tShow this help and exit\n  @config\tLoad the options from the configuration file\n  @cmd1\tcommand 1\n").Equal(buf.String())
+	var caughtPanic interface{}
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		commander.Add("config", cmd)
+	}()
+	goassert.New(t, fmt.Errorf("illegal command name: config")).Equal(caughtPanic)
+}
+
 func TestCommanderAddError(t *testing.T) {
 	var caughtPanic interface{}
 	commander := New(nil)
@@ -120,6 +147,13 @@ func TestCommanderAddError(t *testing.T) {
 		commander.Add("cmd1=", &command1{})
 	}()
 	goassert.New(t, fmt.Errorf("illegal command name: cmd1=")).Equal(caughtPanic)
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		commander.Add("@cmd1", &command1{})
+	}()
+	goassert.New(t, fmt.Errorf("illegal command name: @cmd1")).Equal(caughtPanic)
 }
 
 func TestCommanderParseError(t *testing.T) {
@@ -127,7 +161,7 @@ func TestCommanderParseError(t *testing.T) {
 	cmd1 := &command1{}
 	commander.Add("cmd1", cmd1)
 	goassert.New(t, `expected command name, but got: cmd`).ExpectError(commander.Parse([]string{"cmd", "opt1", "opt2-"}))
-	goassert.New(t, `unknown command: @cmd`).ExpectError(commander.Parse([]string{"@cmd", "opt1", "opt2-"}))
+	goassert.New(t, `unknown command: @cmd \(did you mean @cmd1\? valid commands: @help, @cmd1\)`).ExpectError(commander.Parse([]string{"@cmd", "opt1", "opt2-"}))
 	goassert.New(t, `cannot run @cmd1 multiple times`).ExpectError(commander.Parse([]string{"@cmd1", "opt1", "opt2-", "@cmd1"}))
 	goassert.New(t, `@cmd1: opt2: illegal OptionBool value: =X`).ExpectError(commander.Parse([]string{"@cmd1", "opt1", "opt2=X"}))
 }
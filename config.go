@@ -0,0 +1,557 @@
+package gocommander
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// configValue is a value in a configuration file.
+type configValue struct {
+	value string
+	line  int
+}
+
+// configSection is the option values for a command in a configuration file.
+type configSection struct {
+	line   int
+	values map[string][]configValue
+}
+
+// configFile is a loaded configuration file.
+//
+// The configuration file has a section for each command, which has the option values.
+// If the file extension is ".json", then the file is the JSON object like:
+//
+//	{"CMD": {"OPT1": VALUE, "OPT2": [VALUE, ...], "OPT3": {"KEY": VALUE, ...}}}
+//
+// Otherwise, the file has the INI/TOML-like format like:
+//
+//	# comment
+//	[CMD]
+//	OPT1 = VALUE  # comment
+//	OPT2 = [VALUE, ...]
+//
+// The values are strings, numbers or booleans, and VALUE in the INI/TOML-like format can be quoted by '"'.
+// In the INI/TOML-like format, "#" following a whitespace starts an inline comment unless it is quoted.
+// An array gives multiple values, and the repeated option gives multiple values in the INI/TOML-like format.
+// A JSON object gives the values "KEY:VALUE" for OptionMap.
+//
+// The configuration file can have named profiles.
+// The profile PROFILE is the object at ["profiles"]["PROFILE"] in JSON, or the sections [profiles.PROFILE] and [profiles.PROFILE.CMD] in the INI/TOML-like format.
+// A profile has the option values of commands like the top-level, and can extend another profile by the key "extends".
+// If a profile is selected, then the option values in the profile override the ones in the extended profiles and the top-level.
+// Thus, the command "profiles" cannot be configured.
+type configFile struct {
+	path     FilePath
+	sections map[string]*configSection
+	profiles map[string]*configProfile
+	// active is the selected profile and its ancestors from the most derived one.
+	active []*configProfile
+}
+
+// configProfile is a named set of the option values in a configuration file.
+type configProfile struct {
+	line        int
+	extends     string
+	extendsLine int
+	sections    map[string]*configSection
+}
+
+// loadConfigFile loads the configuration file at the given path.
+//
+// This function returns an error in reading or parsing the file.
+func loadConfigFile(path FilePath) (*configFile, error) {
+	data, err := os.ReadFile(string(path))
+	if err != nil {
+		return nil, err
+	}
+	cfg := &configFile{path: path, sections: map[string]*configSection{}, profiles: map[string]*configProfile{}}
+	if path.Ext() == ".json" {
+		err = cfg.parseJSON(data)
+	} else {
+		err = cfg.parseINI(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// errorf returns an error citing the file and line.
+func (cfg *configFile) errorf(line int, format string, args ...interface{}) error {
+	return fmt.Errorf("%s:%d: %s", cfg.path, line, fmt.Sprintf(format, args...))
+}
+
+// sectionOf returns the section with the given name in sections, and creates it if needed.
+func sectionOf(sections map[string]*configSection, name string, line int) *configSection {
+	section := sections[name]
+	if section == nil {
+		section = &configSection{line: line, values: map[string][]configValue{}}
+		sections[name] = section
+	}
+	return section
+}
+
+// profile returns the profile with the given name, and creates it if needed.
+func (cfg *configFile) profile(name string, line int) *configProfile {
+	profile := cfg.profiles[name]
+	if profile == nil {
+		profile = &configProfile{line: line, sections: map[string]*configSection{}}
+		cfg.profiles[name] = profile
+	}
+	return profile
+}
+
+func (cfg *configFile) parseJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	line := func() int {
+		return 1 + bytes.Count(data[:dec.InputOffset()], []byte("\n"))
+	}
+	token := func() (json.Token, error) {
+		tok, err := dec.Token()
+		if err != nil {
+			if serr, ok := err.(*json.SyntaxError); ok {
+				return nil, cfg.errorf(1+bytes.Count(data[:serr.Offset], []byte("\n")), "%s", serr)
+			}
+			return nil, cfg.errorf(line(), "%s", err)
+		}
+		return tok, nil
+	}
+	expectDelim := func(delim json.Delim) error {
+		tok, err := token()
+		if err != nil {
+			return err
+		}
+		if tok != delim {
+			return cfg.errorf(line(), "expected %s, but got %v", delim, tok)
+		}
+		return nil
+	}
+	scalar := func(tok json.Token) (string, error) {
+		switch v := tok.(type) {
+		case string:
+			return v, nil
+		case json.Number:
+			return v.String(), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+		return "", cfg.errorf(line(), "expected string, number or boolean, but got %v", tok)
+	}
+	key := func() (string, error) {
+		tok, err := token()
+		if err != nil {
+			return "", err
+		}
+		return tok.(string), nil
+	}
+	parseSection := func(section *configSection) error {
+		if err := expectDelim('{'); err != nil {
+			return err
+		}
+		for dec.More() {
+			optname, err := key()
+			if err != nil {
+				return err
+			}
+			tok, err := token()
+			if err != nil {
+				return err
+			}
+			switch tok {
+			case json.Delim('['):
+				for dec.More() {
+					tok, err := token()
+					if err != nil {
+						return err
+					}
+					value, err := scalar(tok)
+					if err != nil {
+						return err
+					}
+					section.values[optname] = append(section.values[optname], configValue{value, line()})
+				}
+				if err := expectDelim(']'); err != nil {
+					return err
+				}
+			case json.Delim('{'):
+				for dec.More() {
+					k, err := key()
+					if err != nil {
+						return err
+					}
+					tok, err := token()
+					if err != nil {
+						return err
+					}
+					value, err := scalar(tok)
+					if err != nil {
+						return err
+					}
+					section.values[optname] = append(section.values[optname], configValue{k + MapSeparator + value, line()})
+				}
+				if err := expectDelim('}'); err != nil {
+					return err
+				}
+			default:
+				value, err := scalar(tok)
+				if err != nil {
+					return err
+				}
+				section.values[optname] = append(section.values[optname], configValue{value, line()})
+			}
+		}
+		return expectDelim('}')
+	}
+	if err := expectDelim('{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		name, err := key()
+		if err != nil {
+			return err
+		}
+		if name != "profiles" {
+			if err := parseSection(sectionOf(cfg.sections, name, line())); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expectDelim('{'); err != nil {
+			return err
+		}
+		for dec.More() {
+			pname, err := key()
+			if err != nil {
+				return err
+			}
+			profile := cfg.profile(pname, line())
+			if err := expectDelim('{'); err != nil {
+				return err
+			}
+			for dec.More() {
+				name, err := key()
+				if err != nil {
+					return err
+				}
+				if name != "extends" {
+					if err := parseSection(sectionOf(profile.sections, name, line())); err != nil {
+						return err
+					}
+					continue
+				}
+				tok, err := token()
+				if err != nil {
+					return err
+				}
+				extends, ok := tok.(string)
+				if !ok {
+					return cfg.errorf(line(), "expected profile name, but got %v", tok)
+				}
+				profile.extends, profile.extendsLine = extends, line()
+			}
+			if err := expectDelim('}'); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim('}'); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim('}'); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return cfg.errorf(line(), "unexpected data after the top-level object")
+	}
+	return nil
+}
+
+// parseINIValue parses the value string of the INI/TOML-like format, and returns the values.
+func parseINIValue(str string) ([]string, error) {
+	str = strings.TrimSpace(str)
+	scalar := func(str string) (string, string, error) {
+		str = strings.TrimLeft(str, " \t")
+		if strings.HasPrefix(str, "\"") {
+			quoted, err := strconv.QuotedPrefix(str)
+			if err != nil {
+				return "", "", fmt.Errorf("illegal quoted value: %s", str)
+			}
+			value, _ := strconv.Unquote(quoted)
+			return value, strings.TrimLeft(str[len(quoted):], " \t"), nil
+		}
+		end := strings.IndexAny(str, ",]")
+		if end < 0 {
+			end = len(str)
+		}
+		return strings.TrimSpace(str[:end]), str[end:], nil
+	}
+	if !strings.HasPrefix(str, "[") {
+		if !strings.HasPrefix(str, "\"") {
+			for i := 1; i < len(str); i++ {
+				if str[i] == '#' && (str[i-1] == ' ' || str[i-1] == '\t') {
+					str = strings.TrimSpace(str[:i])
+					break
+				}
+			}
+			return []string{str}, nil
+		}
+		value, rest, err := scalar(str)
+		if err != nil {
+			return nil, err
+		}
+		if rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, fmt.Errorf("unexpected characters after quoted value: %s", rest)
+		}
+		return []string{value}, nil
+	}
+	values := []string{}
+	rest := strings.TrimLeft(str[len("["):], " \t")
+	for !strings.HasPrefix(rest, "]") {
+		var value string
+		var err error
+		if value, rest, err = scalar(rest); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if strings.HasPrefix(rest, ",") {
+			rest = rest[len(","):]
+		} else if !strings.HasPrefix(rest, "]") {
+			return nil, fmt.Errorf("unterminated array: %s", str)
+		}
+		rest = strings.TrimLeft(rest, " \t")
+	}
+	if rest = strings.TrimSpace(rest[len("]"):]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return nil, fmt.Errorf("unexpected characters after array: %s", rest)
+	}
+	return values, nil
+}
+
+func (cfg *configFile) parseINI(data []byte) error {
+	var section *configSection
+	var profile *configProfile
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") {
+				return cfg.errorf(line, "illegal section: %s", text)
+			}
+			name := strings.TrimSpace(text[len("[") : len(text)-len("]")])
+			section, profile = nil, nil
+			if !strings.HasPrefix(name, "profiles.") {
+				section = sectionOf(cfg.sections, name, line)
+				continue
+			}
+			names := strings.SplitN(name[len("profiles."):], ".", 2)
+			if profile = cfg.profile(names[0], line); len(names) == 2 {
+				section, profile = sectionOf(profile.sections, names[1], line), nil
+			}
+			continue
+		}
+		keyValue := strings.SplitN(text, "=", 2)
+		if len(keyValue) < 2 {
+			return cfg.errorf(line, "expected OPTION = VALUE, but got: %s", text)
+		}
+		optname := strings.TrimSpace(keyValue[0])
+		values, err := parseINIValue(keyValue[1])
+		if err != nil {
+			return cfg.errorf(line, "%s", err)
+		}
+		if profile != nil {
+			if optname != "extends" || len(values) != 1 {
+				return cfg.errorf(line, "expected extends = PROFILE, but got: %s", text)
+			}
+			profile.extends, profile.extendsLine = values[0], line
+			continue
+		}
+		if section == nil {
+			return cfg.errorf(line, "option outside section: %s", text)
+		}
+		for _, value := range values {
+			section.values[optname] = append(section.values[optname], configValue{value, line})
+		}
+	}
+	return scanner.Err()
+}
+
+// check checks that the commands and options in the file are known by the commander, and the profiles extend known profiles without cycles.
+//
+// This function returns an error citing the file and line of the first error.
+func (cfg *configFile) check(commander *Commander) error {
+	if err := cfg.checkSections(commander, cfg.sections); err != nil {
+		return err
+	}
+	pnames := make([]string, 0, len(cfg.profiles))
+	for pname := range cfg.profiles {
+		pnames = append(pnames, pname)
+	}
+	sort.Strings(pnames)
+	for _, pname := range pnames {
+		profile := cfg.profiles[pname]
+		if err := cfg.checkSections(commander, profile.sections); err != nil {
+			return err
+		}
+		visited := map[string]bool{pname: true}
+		for p := profile; p.extends != ""; p = cfg.profiles[p.extends] {
+			if cfg.profiles[p.extends] == nil {
+				return cfg.errorf(p.extendsLine, "unknown profile: %s", p.extends)
+			}
+			if visited[p.extends] {
+				return cfg.errorf(profile.line, "cyclic profile inheritance: %s", pname)
+			}
+			visited[p.extends] = true
+		}
+	}
+	return nil
+}
+
+func (cfg *configFile) checkSections(commander *Commander, sections map[string]*configSection) error {
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		section := sections[name]
+		ctx := commander.Get(name)
+		if ctx == nil {
+			return cfg.errorf(section.line, "unknown command: %s%s", name, unknownSuffix(name, commander.userCommandNames(), "commands"))
+		}
+		for optname, values := range section.values {
+			if ctx.GetOption(optname) == nil {
+				return cfg.errorf(values[0].line, "unknown option of @%s: %s%s", name, optname, unknownSuffix(optname, ctx.optionNames(), "options"))
+			}
+		}
+	}
+	return nil
+}
+
+// selectProfile selects the profile with the given name.
+// The profiles must be checked by check.
+//
+// This function returns an error if the profile is unknown.
+func (cfg *configFile) selectProfile(name string) error {
+	if cfg.profiles[name] == nil {
+		return fmt.Errorf("unknown profile: %s", name)
+	}
+	cfg.active = nil
+	for ; name != ""; name = cfg.profiles[name].extends {
+		cfg.active = append(cfg.active, cfg.profiles[name])
+	}
+	return nil
+}
+
+// values returns the values of the option with the given name of the command with the given name.
+// The values in the selected profile override the ones in the extended profiles and the top-level.
+func (cfg *configFile) values(cmdname, optname string) []configValue {
+	if cfg == nil {
+		return nil
+	}
+	for _, profile := range cfg.active {
+		if section := profile.sections[cmdname]; section != nil {
+			if values, ok := section.values[optname]; ok {
+				return values
+			}
+		}
+	}
+	if section := cfg.sections[cmdname]; section != nil {
+		return section.values[optname]
+	}
+	return nil
+}
+
+// configCommand is the built-in command @config enabled by Settings.ConfigCommand.
+type configCommand struct{}
+
+// Description is for interface Command.
+func (cmd *configCommand) Description() string {
+	return "Load the options from the configuration file"
+}
+
+// Init is for interface Command.
+func (cmd *configCommand) Init(ctx *Context) {
+	ctx.AddOption("file", NewOptionFilePath("", true, FilePathFile), "configuration file (JSON if the extension is .json, otherwise INI/TOML-like)")
+	var attrs []OptionAttribute
+	if env := ctx.commander.settings.ConfigProfileEnv; env != "" {
+		attrs = append(attrs, EnvVar(env))
+	}
+	ctx.AddOption("profile", NewOptionString(""), "profile in the configuration file", attrs...)
+	ctx.AddOption("show", NewOptionBool(false), "show the effective options of the commands with their sources and exit")
+}
+
+// Run is for interface Command.
+func (cmd *configCommand) Run(ctx *Context) error {
+	return nil
+}
+
+// loadConfig loads the configuration file specified by @config, or Settings.ConfigFile if it exists, and selects the profile specified by @config.
+// The options of @config fall back on the environment variables like the other commands (such as $PREFIX_CONFIG_PROFILE where PREFIX is Settings.EnvPrefix), and the profile falls back on Settings.ConfigProfileEnv if it is not empty.
+//
+// This function returns an error in loading the file, if the file has an unknown command or option, or if the profile is unknown.
+func (commander *Commander) loadConfig() error {
+	commander.cfg = nil
+	if err := commander.config.complete(); err != nil {
+		return err
+	}
+	profile := commander.config.GetOption("profile").(*OptionString).Get()
+	path := commander.settings.ConfigFile
+	if commander.config.IsSet("file") {
+		path = commander.config.GetOption("file").(*OptionFilePath).Get()
+	} else if path != "" {
+		if path = path.Expand(); FilePathFile.Check(path) != nil {
+			path = ""
+		}
+	}
+	if path == "" {
+		if profile != "" {
+			return fmt.Errorf("unknown profile (no configuration file): %s", profile)
+		}
+		return nil
+	}
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		return err
+	}
+	if err := cfg.check(commander); err != nil {
+		return err
+	}
+	if profile != "" {
+		if err := cfg.selectProfile(profile); err != nil {
+			return err
+		}
+	}
+	commander.cfg = cfg
+	return nil
+}
+
+// ShowConfig writes the effective options of the queued commands with their sources to the writer of the commander's logger.
+func (commander *Commander) ShowConfig() {
+	w := commander.Logger().Writer()
+	path := "none"
+	if commander.cfg != nil {
+		path = string(commander.cfg.path)
+		if len(commander.cfg.active) > 0 {
+			path += fmt.Sprintf(" (profile %s)", commander.config.GetOption("profile").(*OptionString).Get())
+		}
+	}
+	fmt.Fprintf(w, "configuration file: %s\n", path)
+	for _, name := range commander.queue {
+		ctx := commander.ctxs[name]
+		fmt.Fprintf(w, "@%s:\n", name)
+		for _, optname := range ctx.optionNames() {
+			fmt.Fprintf(w, "  %s:%s\t(%s)\n", optname, ctx.valueString(optname), ctx.metas[optname].source)
+		}
+	}
+}
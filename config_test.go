@@ -0,0 +1,207 @@
+package gocommander
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+	"github.com/hiro4bbh/go-log"
+)
+
+type configTestCommand struct {
+	Threads int               `option:"threads" desc:"threads" default:"1"`
+	Rate    float64           `option:"rate" desc:"rate"`
+	Debug   bool              `option:"debug" desc:"debug mode"`
+	Name    string            `option:"name" desc:"name"`
+	Include []string          `option:"include" desc:"include"`
+	Labels  map[string]string `option:"label" desc:"label"`
+}
+
+func (cmd *configTestCommand) Description() string {
+	return "config test command"
+}
+
+func (cmd *configTestCommand) Init(ctx *Context) {
+	ctx.Bind(cmd)
+}
+
+func (cmd *configTestCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func writeTestFile(t *testing.T, dir FilePath, name, content string) FilePath {
+	path := dir.Join(FilePath(name))
+	goassert.New(t).SucceedWithoutError(os.WriteFile(string(path), []byte(content), 0640))
+	return path
+}
+
+func TestConfigJSON(t *testing.T) {
+	dir := FilePath(t.TempDir())
+	path := writeTestFile(t, dir, "config.json", `{
+  "train": {
+    "threads": 4,
+    "rate": 0.5,
+    "debug": true,
+    "name": "from config",
+    "include": ["a", "b"],
+    "label": {"env": "dev", "team": "ml"}
+  }
+}
+`)
+	commander := New(&Settings{EnvPrefix: "TEST_", ConfigCommand: true})
+	cmd := &configTestCommand{}
+	commander.Add("train", cmd)
+	t.Setenv("TEST_TRAIN_RATE", "0.25")
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "@train", "name=from argv"}))
+	goassert.New(t, &configTestCommand{
+		Threads: 4,
+		Rate:    0.25,
+		Debug:   true,
+		Name:    "from argv",
+		Include: []string{"a", "b"},
+		Labels:  map[string]string{"env": "dev", "team": "ml"},
+	}).Equal(cmd)
+	goassert.New(t, true).Equal(commander.Get("train").IsSet("threads"))
+
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@train"}))
+	goassert.New(t, 1).Equal(cmd.Threads)
+	goassert.New(t, `cannot run @config multiple times`).ExpectError(commander.Parse([]string{"@config", "file=" + string(path), "@config"}))
+}
+
+func TestConfigINI(t *testing.T) {
+	dir := FilePath(t.TempDir())
+	path := writeTestFile(t, dir, "config.ini", `# comment
+; comment
+[train]
+threads = 4
+debug = true # comment
+name = "quoted # value"  # comment
+include = a
+include = [b, "c,d" ]
+label = env:dev#1	# comment
+`)
+	commander := New(&Settings{ConfigFile: path})
+	cmd := &configTestCommand{}
+	commander.Add("train", cmd)
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@train", "include=x"}))
+	goassert.New(t, &configTestCommand{
+		Threads: 4,
+		Debug:   true,
+		Name:    "quoted # value",
+		Include: []string{"x"},
+		Labels:  map[string]string{"env": "dev#1"},
+	}).Equal(cmd)
+
+	commander = New(&Settings{ConfigFile: dir.Join("unknown.ini"), ConfigCommand: true})
+	commander.Add("train", cmd)
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@train"}))
+	goassert.New(t, 1).Equal(cmd.Threads)
+	goassert.New(t, `@config: file: OptionFilePath .*/unknown.ini does not exist: =.*/unknown.ini`).ExpectError(commander.Parse([]string{"@config", "file=" + string(dir.Join("unknown.ini")), "@train"}))
+}
+
+func TestConfigErrors(t *testing.T) {
+	dir := FilePath(t.TempDir())
+	commander := New(&Settings{ConfigCommand: true})
+	commander.Add("train", &configTestCommand{})
+	for _, c := range []struct {
+		name, content, err string
+	}{
+		{"unknown-command.ini", "[train]\nthreads = 1\n[test]\n", `@config: .*/unknown-command.ini:3: unknown command: test \(valid commands: train\)`},
+		{"unknown-option.ini", "[train]\n\nthread = 1\n", `@config: .*/unknown-option.ini:3: unknown option of @train: thread \(did you mean threads\?\)`},
+		{"outside.ini", "threads = 1\n", `@config: .*/outside.ini:1: option outside section: threads = 1`},
+		{"section.ini", "[train\n", `@config: .*/section.ini:1: illegal section: \[train`},
+		{"key-value.ini", "[train]\nthreads\n", `@config: .*/key-value.ini:2: expected OPTION = VALUE, but got: threads`},
+		{"quoted.ini", "[train]\nname = \"a\n", `@config: .*/quoted.ini:2: illegal quoted value: "a`},
+		{"after-quoted.ini", "[train]\nname = \"a\" b\n", `@config: .*/after-quoted.ini:2: unexpected characters after quoted value: b`},
+		{"array.ini", "[train]\ninclude = [a b\n", `@config: .*/array.ini:2: unterminated array: \[a b`},
+		{"after-array.ini", "[train]\ninclude = [a] b\n", `@config: .*/after-array.ini:2: unexpected characters after array: b`},
+		{"value.ini", "[train]\n\nthreads = x\n", `@train: threads: .*/value.ini:3: illegal OptionInt value: =x`},
+		{"syntax.json", "{\n  \"train\": {\n    \"threads\": 1,\n  }\n}\n", `@config: .*/syntax.json:3: invalid character ',' looking for beginning of value`},
+		{"section.json", "{\n  \"train\": 1\n}\n", `@config: .*/section.json:2: expected {, but got 1`},
+		{"null.json", "{\n  \"train\": {\n    \"name\": null\n  }\n}\n", `@config: .*/null.json:3: expected string, number or boolean, but got <nil>`},
+		{"trailing.json", "{}\n{}\n", `@config: .*/trailing.json:2: unexpected data after the top-level object`},
+		{"value.json", "{\n  \"train\": {\n    \"debug\": \"yes\"\n  }\n}\n", `@train: debug: .*/value.json:3: illegal OptionBool value: yes`},
+	} {
+		path := writeTestFile(t, dir, c.name, c.content)
+		goassert.New(t, c.err).ExpectError(commander.Parse([]string{"@config", "file=" + string(path), "@train"}))
+	}
+}
+
+func TestConfigHelp(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "}), ConfigCommand: true})
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "help"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\n@config: Load the options from the configuration file\noptions:\n  help\tShow this help and exit\n  file=FILE\tconfiguration file (JSON if the extension is .json, otherwise INI/TOML-like)\n  profile=VALUE\tprofile in the configuration file\n  show[+-]\tshow the effective options of the commands with their sources and exit\n").Equal(buf.String())
+}
+
+func TestConfigProfiles(t *testing.T) {
+	dir := FilePath(t.TempDir())
+	jsonPath := writeTestFile(t, dir, "config.json", `{
+  "train": {"threads": 1, "name": "base", "include": ["base"]},
+  "profiles": {
+    "local": {"train": {"threads": 2, "include": ["local"]}},
+    "dev": {"extends": "local", "train": {"name": "dev"}},
+    "staging": {"extends": "dev", "train": {"threads": 8}}
+  }
+}
+`)
+	iniPath := writeTestFile(t, dir, "config.ini", `[train]
+threads = 1
+name = base
+include = base
+[profiles.local.train]
+threads = 2
+include = local
+[profiles.dev]
+extends = local
+[profiles.dev.train]
+name = dev
+[profiles.staging]
+extends = dev
+[profiles.staging.train]
+threads = 8
+`)
+	commander := New(&Settings{EnvPrefix: "TEST_", ConfigCommand: true})
+	cmd := &configTestCommand{}
+	commander.Add("train", cmd)
+	for _, path := range []FilePath{jsonPath, iniPath} {
+		goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "@train"}))
+		goassert.New(t, []interface{}{1, "base", []string{"base"}}).Equal([]interface{}{cmd.Threads, cmd.Name, cmd.Include})
+		goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "profile=local", "@train"}))
+		goassert.New(t, []interface{}{2, "base", []string{"local"}}).Equal([]interface{}{cmd.Threads, cmd.Name, cmd.Include})
+		goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "profile=dev", "@train"}))
+		goassert.New(t, []interface{}{2, "dev", []string{"local"}}).Equal([]interface{}{cmd.Threads, cmd.Name, cmd.Include})
+		t.Setenv("TEST_CONFIG_PROFILE", "staging")
+		goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "@train"}))
+		goassert.New(t, []interface{}{8, "dev", []string{"local"}}).Equal([]interface{}{cmd.Threads, cmd.Name, cmd.Include})
+		goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "profile=dev", "@train", "name=argv"}))
+		goassert.New(t, []interface{}{2, "argv", []string{"local"}}).Equal([]interface{}{cmd.Threads, cmd.Name, cmd.Include})
+		goassert.New(t, `@config: unknown profile: prod`).ExpectError(commander.Parse([]string{"@config", "file=" + string(path), "profile=prod", "@train"}))
+		t.Setenv("TEST_CONFIG_PROFILE", "")
+	}
+	goassert.New(t, `@config: unknown profile \(no configuration file\): dev`).ExpectError(commander.Parse([]string{"@config", "profile=dev", "@train"}))
+
+	commander = New(&Settings{ConfigFile: iniPath, ConfigProfileEnv: "APP_PROFILE", ConfigCommand: true})
+	commander.Add("train", cmd)
+	t.Setenv("APP_PROFILE", "staging")
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@train"}))
+	goassert.New(t, []interface{}{8, "dev", []string{"local"}}).Equal([]interface{}{cmd.Threads, cmd.Name, cmd.Include})
+	goassert.New(t, Source{SourceEnv, "APP_PROFILE"}).Equal(commander.config.Source("profile"))
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "profile=local", "@train"}))
+	goassert.New(t, 2).Equal(cmd.Threads)
+
+	for _, c := range []struct {
+		name, content, err string
+	}{
+		{"unknown-extends.json", "{\"profiles\": {\n\"dev\": {\"extends\": \"base\"}\n}}\n", `@config: .*/unknown-extends.json:2: unknown profile: base`},
+		{"cyclic.ini", "[profiles.a]\nextends = b\n[profiles.b]\nextends = a\n", `@config: .*/cyclic.ini:1: cyclic profile inheritance: a`},
+		{"extends.json", "{\"profiles\": {\"dev\": {\n\"extends\": 1}}}\n", `@config: .*/extends.json:2: expected profile name, but got 1`},
+		{"extends.ini", "[profiles.dev]\nthreads = 1\n", `@config: .*/extends.ini:2: expected extends = PROFILE, but got: threads = 1`},
+		{"profile-option.ini", "[profiles.dev.train]\nthread = 1\n", `@config: .*/profile-option.ini:2: unknown option of @train: thread \(did you mean threads\?\)`},
+	} {
+		path := writeTestFile(t, dir, c.name, c.content)
+		goassert.New(t, c.err).ExpectError(commander.Parse([]string{"@config", "file=" + string(path), "@train"}))
+	}
+}
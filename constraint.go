@@ -0,0 +1,168 @@
+package gocommander
+
+import (
+	"fmt"
+	"strings"
+)
+
+// constraint is a constraint among options checked after parsing.
+type constraint struct {
+	// description is shown in Context.Help.
+	description string
+	// check returns an error if the constraint is violated.
+	check func(ctx *Context) error
+	// conflicts returns the names of the options which cannot be specified with the option with the given name, or nil.
+	conflicts func(name string) []string
+}
+
+// checkOptionNames calls panic if some of the given option names are unknown.
+func (ctx *Context) checkOptionNames(names []string) {
+	for _, name := range names {
+		if _, ok := ctx.opts[name]; !ok {
+			panic(fmt.Errorf("unknown option: %s", name))
+		}
+	}
+}
+
+// setNames returns the names of the specified options in names.
+func (ctx *Context) setNames(names []string) []string {
+	set := []string{}
+	for _, name := range names {
+		if ctx.IsSet(name) {
+			set = append(set, name)
+		}
+	}
+	return set
+}
+
+// overridden returns true if the option with the given name cannot be set from the given source kind, because a conflicting option is specified by a higher-priority source.
+// For example, the option from the configuration file is ignored if a conflicting option is specified on the command line.
+func (ctx *Context) overridden(name string, kind SourceKind) bool {
+	for _, c := range ctx.constraints {
+		if c.conflicts == nil {
+			continue
+		}
+		for _, other := range c.conflicts(name) {
+			if ctx.metas[other].source.Kind > kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Exclusive adds the constraint that at most one of the options with the given names is specified.
+// The options are not set from the environment variables or the configuration file if another one is specified by a higher-priority source.
+//
+// This function calls panic if some of the names are unknown.
+func (ctx *Context) Exclusive(names ...string) {
+	ctx.checkOptionNames(names)
+	ctx.constraints = append(ctx.constraints, constraint{
+		description: fmt.Sprintf("at most one of %s", strings.Join(names, ", ")),
+		check: func(ctx *Context) error {
+			if set := ctx.setNames(names); len(set) > 1 {
+				return fmt.Errorf("options %s are mutually exclusive", strings.Join(set, ", "))
+			}
+			return nil
+		},
+		conflicts: func(name string) []string {
+			others := []string{}
+			for _, other := range names {
+				if other != name {
+					others = append(others, other)
+				}
+			}
+			if len(others) == len(names) {
+				return nil
+			}
+			return others
+		},
+	})
+}
+
+// AllOrNone adds the constraint that all or none of the options with the given names are specified.
+//
+// This function calls panic if some of the names are unknown.
+func (ctx *Context) AllOrNone(names ...string) {
+	ctx.checkOptionNames(names)
+	ctx.constraints = append(ctx.constraints, constraint{
+		description: fmt.Sprintf("all or none of %s", strings.Join(names, ", ")),
+		check: func(ctx *Context) error {
+			if set := ctx.setNames(names); len(set) > 0 && len(set) < len(names) {
+				missing := []string{}
+				for _, name := range names {
+					if !ctx.IsSet(name) {
+						missing = append(missing, name)
+					}
+				}
+				return fmt.Errorf("options %s must be specified together (missing %s)", strings.Join(names, ", "), strings.Join(missing, ", "))
+			}
+			return nil
+		},
+	})
+}
+
+// Requires adds the constraint that the options with the given others are specified if the option with the given name is specified.
+//
+// This function calls panic if some of the names are unknown.
+func (ctx *Context) Requires(name string, others ...string) {
+	ctx.checkOptionNames(append([]string{name}, others...))
+	ctx.constraints = append(ctx.constraints, constraint{
+		description: fmt.Sprintf("%s requires %s", name, strings.Join(others, ", ")),
+		check: func(ctx *Context) error {
+			if !ctx.IsSet(name) {
+				return nil
+			}
+			missing := []string{}
+			for _, other := range others {
+				if !ctx.IsSet(other) {
+					missing = append(missing, other)
+				}
+			}
+			if len(missing) > 0 {
+				return fmt.Errorf("option %s requires %s", name, strings.Join(missing, ", "))
+			}
+			return nil
+		},
+	})
+}
+
+// Conflicts adds the constraint that none of the options with the given others are specified if the option with the given name is specified.
+// The conflicting options are overridden by a higher-priority source like Exclusive.
+//
+// This function calls panic if some of the names are unknown.
+func (ctx *Context) Conflicts(name string, others ...string) {
+	ctx.checkOptionNames(append([]string{name}, others...))
+	ctx.constraints = append(ctx.constraints, constraint{
+		description: fmt.Sprintf("%s conflicts with %s", name, strings.Join(others, ", ")),
+		check: func(ctx *Context) error {
+			if !ctx.IsSet(name) {
+				return nil
+			}
+			if set := ctx.setNames(others); len(set) > 0 {
+				return fmt.Errorf("option %s conflicts with %s", name, strings.Join(set, ", "))
+			}
+			return nil
+		},
+		conflicts: func(n string) []string {
+			if n == name {
+				return others
+			}
+			for _, other := range others {
+				if n == other {
+					return []string{name}
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// Validate adds the custom constraint checked by the given fn with the given description.
+// fn should return an error if the constraint is violated.
+func (ctx *Context) Validate(description string, fn func(ctx *Context) error) {
+	ctx.constraints = append(ctx.constraints, constraint{
+		description: description,
+		check:       fn,
+	})
+}
@@ -0,0 +1,128 @@
+package gocommander
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+	"github.com/hiro4bbh/go-log"
+)
+
+type constraintCommand struct{}
+
+func (cmd *constraintCommand) Description() string {
+	return "constraint command"
+}
+
+func (cmd *constraintCommand) Init(ctx *Context) {
+	ctx.AddOption("output", NewOptionString(""), "output file")
+	ctx.AddOption("stdout", NewOptionBool(false), "write to stdout")
+	ctx.AddOption("key", NewOptionString(""), "key file")
+	ctx.AddOption("cert", NewOptionString(""), "certificate file")
+	ctx.AddOption("user", NewOptionString(""), "user")
+	ctx.AddOption("password", NewOptionString(""), "password")
+	ctx.AddOption("anonymous", NewOptionBool(false), "anonymous access")
+	ctx.AddOption("min", NewOptionInt(0), "minimum")
+	ctx.AddOption("max", NewOptionInt(10), "maximum")
+	ctx.Exclusive("output", "stdout")
+	ctx.Requires("key", "cert")
+	ctx.AllOrNone("user", "password")
+	ctx.Conflicts("anonymous", "user", "key")
+	ctx.Validate("min <= max", func(ctx *Context) error {
+		if min, max := ctx.GetOption("min").(*OptionInt).Get(), ctx.GetOption("max").(*OptionInt).Get(); min > max {
+			return fmt.Errorf("min %d is greater than max %d", min, max)
+		}
+		return nil
+	})
+}
+
+func (cmd *constraintCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func TestConstraints(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "})})
+	commander.Add("cmd", &constraintCommand{})
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "output=a", "key=k", "cert=c", "user=u", "password=p", "min=3"}))
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "stdout", "anonymous"}))
+	goassert.New(t, `@cmd: options output, stdout are mutually exclusive`).ExpectError(commander.Parse([]string{"@cmd", "output=a", "stdout"}))
+	goassert.New(t, `@cmd: option key requires cert`).ExpectError(commander.Parse([]string{"@cmd", "key=k"}))
+	goassert.New(t, `@cmd: options user, password must be specified together \(missing password\)`).ExpectError(commander.Parse([]string{"@cmd", "user=u"}))
+	goassert.New(t, `@cmd: option key requires cert; options user, password must be specified together \(missing password\); option anonymous conflicts with user, key`).ExpectError(commander.Parse([]string{"@cmd", "anonymous", "user=u", "key=k"}))
+	goassert.New(t, `@cmd: min 11 is greater than max 10`).ExpectError(commander.Parse([]string{"@cmd", "min=11"}))
+
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "help"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\n@cmd: constraint command\noptions:\n  help\tShow this help and exit\n  anonymous[+-]\tanonymous access\n  cert=VALUE\tcertificate file\n  key=VALUE\tkey file\n  max=INT\tmaximum (default 10)\n  min=INT\tminimum\n  output=VALUE\toutput file\n  password=VALUE\tpassword\n  stdout[+-]\twrite to stdout\n  user=VALUE\tuser\nconstraints:\n  at most one of output, stdout\n  key requires cert\n  all or none of user, password\n  anonymous conflicts with user, key\n  min <= max\n").Equal(buf.String())
+}
+
+func TestConstraintsUnknownOption(t *testing.T) {
+	ctx := newContext(New(nil), nil)
+	ctx.AddOption("a", NewOptionBool(false), "a")
+	var caughtPanic interface{}
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		ctx.Exclusive("a", "b")
+	}()
+	goassert.New(t, fmt.Errorf("unknown option: b")).Equal(caughtPanic)
+}
+
+type boundConstraintCommand struct {
+	Min int `option:"min" desc:"minimum"`
+	Max int `option:"max" desc:"maximum" default:"10"`
+}
+
+func (cmd *boundConstraintCommand) Description() string {
+	return "bound constraint command"
+}
+
+func (cmd *boundConstraintCommand) Init(ctx *Context) {
+	ctx.Bind(cmd)
+	ctx.Validate("min <= max", func(ctx *Context) error {
+		if cmd.Min > cmd.Max {
+			return fmt.Errorf("min %d is greater than max %d", cmd.Min, cmd.Max)
+		}
+		return nil
+	})
+}
+
+func (cmd *boundConstraintCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func TestConstraintsBound(t *testing.T) {
+	commander := New(nil)
+	commander.Add("cmd", &boundConstraintCommand{})
+	goassert.New(t, `@cmd: min 20 is greater than max 10`).ExpectError(commander.Parse([]string{"@cmd", "min=20"}))
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "min=20", "max=30"}))
+}
+
+func TestConstraintsSources(t *testing.T) {
+	path := writeTestFile(t, FilePath(t.TempDir()), "config.ini", "[cmd]\noutput = out.txt\nuser = u\n")
+	commander := New(&Settings{ConfigFile: path, EnvPrefix: "TEST_"})
+	commander.Add("cmd", &constraintCommand{})
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "stdout", "password=p"}))
+	ctx := commander.Get("cmd")
+	goassert.New(t, false).Equal(ctx.IsSet("output"))
+	goassert.New(t, "").EqualWithoutError(Lookup[string](ctx, "output"))
+	goassert.New(t, true).Equal(ctx.IsSet("user"))
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "anonymous"}))
+	ctx = commander.Get("cmd")
+	goassert.New(t, false).Equal(ctx.IsSet("user"))
+	goassert.New(t, true).Equal(ctx.IsSet("output"))
+	goassert.New(t, `@cmd: options output, stdout are mutually exclusive`).ExpectError(commander.Parse([]string{"@cmd", "output=a", "stdout", "password=p"}))
+	t.Setenv("TEST_CMD_STDOUT", "true")
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "password=p"}))
+	ctx = commander.Get("cmd")
+	goassert.New(t, Source{SourceEnv, "TEST_CMD_STDOUT"}).Equal(ctx.Source("stdout"))
+	goassert.New(t, false).Equal(ctx.IsSet("output"))
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "output=a", "password=p"}))
+	ctx = commander.Get("cmd")
+	goassert.New(t, false).Equal(ctx.IsSet("stdout"))
+	goassert.New(t, `@cmd: options output, stdout are mutually exclusive`).ExpectError(commander.Parse([]string{"@cmd", "output=a", "stdout", "password=p"}))
+	goassert.New(t, `@cmd: option anonymous conflicts with user`).ExpectError(commander.Parse([]string{"@cmd", "anonymous", "user=u", "password=p"}))
+}
@@ -22,11 +22,19 @@ type Command interface {
 
 // Context is the command instance with a ContextHandlers.
 type Context struct {
-	commander *Commander
-	cmd       Command
-	help      bool
-	opts      map[string]Option
-	descs     map[string]string
+	commander   *Commander
+	name        string
+	cmd         Command
+	help        bool
+	opts        map[string]Option
+	descs       map[string]string
+	metas       map[string]*optionMeta
+	constraints []constraint
+	bindings    []binding
+	// arguments are the positional argument names in order.
+	arguments []string
+	// args are the raw arguments following "--".
+	args []string
 }
 
 func newContext(commander *Commander, cmd Command) *Context {
@@ -35,21 +43,165 @@ func newContext(commander *Commander, cmd Command) *Context {
 		cmd:       cmd,
 		opts:      map[string]Option{},
 		descs:     map[string]string{},
+		metas:     map[string]*optionMeta{},
 	}
 }
 
-// AddOption adds the given opt with the given name.
+// AddOption adds the given opt with the given name and attributes.
 //
-// This function calls panic if the given name is already used or the given name is illegal.
+// This function calls panic if the given name is already used, the given name is illegal, or Variadic is given.
 // The illegal name are "h" or "help".
-func (ctx *Context) AddOption(name string, opt Option, description string) {
+func (ctx *Context) AddOption(name string, opt Option, description string, attrs ...OptionAttribute) {
+	meta := &optionMeta{}
+	for _, attr := range attrs {
+		attr(meta)
+	}
+	if meta.variadic {
+		panic(fmt.Errorf("option %s cannot be variadic", name))
+	}
+	ctx.addOption(name, opt, description, meta)
+}
+
+// addOption adds the given opt with the given name and meta.
+//
+// This function calls panic if the given name is already used or the given name is illegal.
+func (ctx *Context) addOption(name string, opt Option, description string, meta *optionMeta) {
 	if _, ok := ctx.opts[name]; ok {
 		panic(fmt.Errorf("option name %s is already used", name))
 	}
 	if name == "help" {
 		panic(fmt.Errorf("illegal option name: %s", name))
 	}
-	ctx.opts[name], ctx.descs[name] = opt, description
+	ctx.opts[name], ctx.descs[name], ctx.metas[name] = opt, description, meta
+}
+
+// complete populates the fields bound by Bind, and checks the required options and the constraints.
+// The fields are populated first, so the constraints can read them.
+//
+// This function returns an error reporting all missing required options and violated constraints.
+func (ctx *Context) complete() error {
+	if err := ctx.loadEnv(); err != nil {
+		return err
+	}
+	if err := ctx.loadConfig(); err != nil {
+		return err
+	}
+	for _, b := range ctx.bindings {
+		b.populate()
+	}
+	errs := []string{}
+	missing, missingArgs := []string{}, []string{}
+	for _, name := range ctx.optionNames() {
+		if meta := ctx.metas[name]; meta.required && meta.source.Kind == SourceDefault && !ctx.isArgument(name) {
+			missing = append(missing, name)
+		}
+	}
+	for _, name := range ctx.arguments {
+		if meta := ctx.metas[name]; meta.required && meta.source.Kind == SourceDefault {
+			missingArgs = append(missingArgs, name)
+		}
+	}
+	if len(missingArgs) > 0 {
+		errs = append(errs, fmt.Sprintf("missing required arguments: %s", strings.Join(missingArgs, ", ")))
+	}
+	if len(missing) > 0 {
+		errs = append(errs, fmt.Sprintf("missing required options: %s", strings.Join(missing, ", ")))
+	}
+	for _, c := range ctx.constraints {
+		if err := c.check(ctx); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// envName returns the environment variable name which the option with the given name falls back on, or the empty string if no variable is used.
+func (ctx *Context) envName(name string) string {
+	if env := ctx.metas[name].env; env != "" {
+		return env
+	}
+	prefix := ctx.commander.EnvPrefix()
+	if prefix == "" {
+		return ""
+	}
+	toEnv := func(str string) string {
+		return strings.Map(func(r rune) rune {
+			if ('0' <= r && r <= '9') || ('A' <= r && r <= 'Z') {
+				return r
+			}
+			return '_'
+		}, strings.ToUpper(str))
+	}
+	if ctx.name == "" {
+		return prefix + toEnv(name)
+	}
+	return prefix + toEnv(ctx.name) + "_" + toEnv(name)
+}
+
+// isArgument returns true if the option with the given name is a positional argument.
+func (ctx *Context) isArgument(name string) bool {
+	for _, arg := range ctx.arguments {
+		if name == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// optionNames returns the sorted option names.
+func (ctx *Context) optionNames() []string {
+	names := make([]string, 0, len(ctx.opts))
+	for name := range ctx.opts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadEnv sets the options not specified from the environment variables.
+// The options conflicting with the options specified on the command line are skipped (see Exclusive and Conflicts).
+//
+// This function returns an error in setting the option.
+func (ctx *Context) loadEnv() error {
+	for _, name := range ctx.optionNames() {
+		meta := ctx.metas[name]
+		env := ctx.envName(name)
+		if meta.source.Kind != SourceDefault || env == "" || ctx.overridden(name, SourceEnv) {
+			continue
+		}
+		value, err := Env(env).Unwrap()
+		if err != nil {
+			continue
+		}
+		if err := meta.setValue(ctx.opts[name], value, Source{SourceEnv, env}); err != nil {
+			return fmt.Errorf("%s: $%s: %s", name, env, err)
+		}
+	}
+	return nil
+}
+
+// loadConfig sets the options specified by neither the command line nor the environment variables from the commander's configuration file.
+// The options conflicting with the options specified by them are skipped (see Exclusive and Conflicts).
+//
+// This function returns an error in setting the option.
+func (ctx *Context) loadConfig() error {
+	cfg := ctx.commander.cfg
+	for _, name := range ctx.optionNames() {
+		meta := ctx.metas[name]
+		if meta.source.Kind != SourceDefault || ctx.overridden(name, SourceConfig) {
+			continue
+		}
+		for _, v := range cfg.values(ctx.name, name) {
+			location := fmt.Sprintf("%s:%d", cfg.path, v.line)
+			if err := meta.setValue(ctx.opts[name], v.value, Source{SourceConfig, location}); err != nil {
+				return fmt.Errorf("%s: %s: %s", name, location, err)
+			}
+		}
+	}
+	return nil
 }
 
 // GetOption returns the Option with the given option name.
@@ -58,28 +210,75 @@ func (ctx *Context) GetOption(name string) Option {
 }
 
 // Help writes the help message to the writer of the commander's logger.
+// The positional arguments are listed in the synopsis and the arguments section.
 func (ctx *Context) Help(cmdname string) {
 	w := ctx.commander.Logger().Writer()
-	fmt.Fprintf(w, "%s\n%s\n\n@%s: %s\noptions:\n", ctx.commander.Name(), ctx.commander.Copyright(), cmdname, ctx.cmd.Description())
-	names := make([]string, 0, len(ctx.opts)+1)
-	names = append(names, "help")
-	for name := range ctx.opts {
-		names = append(names, name)
+	synopsis := "@" + cmdname
+	if len(ctx.arguments) > 0 {
+		synopsis += " " + ctx.synopsis()
 	}
-	sort.Strings(names[1:])
-	for i, name := range names {
-		if i == 0 {
-			fmt.Fprintf(w, "  %s\tShow this help and exit\n", name)
-		} else {
-			opt, desc, defaultPart := ctx.opts[name], ctx.descs[name], ""
-			switch defaultStr := opt.String(); defaultStr {
-			case "", "false", "0", "0.0", "\"\"":
-			default:
-				defaultPart = fmt.Sprintf(" (default %s)", defaultStr)
-			}
-			fmt.Fprintf(w, "  %s%s\t%s%s\n", name, opt.ValueFormat(), desc, defaultPart)
+	fmt.Fprintf(w, "%s\n%s\n\n%s: %s\n", ctx.commander.Name(), ctx.commander.Copyright(), synopsis, ctx.cmd.Description())
+	if len(ctx.arguments) > 0 {
+		fmt.Fprintf(w, "arguments:\n")
+		for _, name := range ctx.arguments {
+			fmt.Fprintf(w, "  %s\n", ctx.optionHelp(name))
 		}
 	}
+	fmt.Fprintf(w, "options:\n  help\tShow this help and exit\n")
+	for _, name := range ctx.optionNames() {
+		if !ctx.isArgument(name) {
+			fmt.Fprintf(w, "  %s\n", ctx.optionHelp(name))
+		}
+	}
+	if len(ctx.constraints) > 0 {
+		fmt.Fprintf(w, "constraints:\n")
+		for _, c := range ctx.constraints {
+			fmt.Fprintf(w, "  %s\n", c.description)
+		}
+	}
+}
+
+// optionHelp returns the help line of the option with the given name.
+func (ctx *Context) optionHelp(name string) string {
+	opt, desc, defaultPart := ctx.opts[name], ctx.descs[name], ""
+	if ctx.metas[name].required {
+		desc += " (required)"
+	}
+	if ctx.metas[name].secret {
+		desc += fmt.Sprintf(" (secret, or %s-file=PATH or %s-env=VAR)", name, name)
+	} else {
+		switch defaultStr := opt.String(); defaultStr {
+		case "", "false", "0", "0.0", "0s", "\"\"", "[]", "{}":
+		default:
+			defaultPart = fmt.Sprintf("default %s", defaultStr)
+		}
+	}
+	if env := ctx.envName(name); env != "" {
+		if defaultPart != "" {
+			defaultPart += ", "
+		}
+		defaultPart += fmt.Sprintf("env $%s", env)
+	}
+	if defaultPart != "" {
+		defaultPart = fmt.Sprintf(" (%s)", defaultPart)
+	}
+	return fmt.Sprintf("%s%s\t%s%s", name, opt.ValueFormat(), desc, defaultPart)
+}
+
+// unknownOptionError returns the error reporting the unknown option with the given key and the suggestions.
+func (ctx *Context) unknownOptionError(key string) error {
+	return fmt.Errorf("unknown option: %s%s", key, unknownSuffix(key, append([]string{"help"}, ctx.optionNames()...), "options"))
+}
+
+// Args returns the raw arguments following the terminator "--".
+func (ctx *Context) Args() []string {
+	return ctx.args
+}
+
+// IsSet returns true if the option with the given name is specified, otherwise the option has the default value.
+func (ctx *Context) IsSet(name string) bool {
+	meta := ctx.metas[name]
+	return meta != nil && meta.source.Kind != SourceDefault
 }
 
 // Logger returns the command's logger.
@@ -89,28 +288,40 @@ func (ctx *Context) Logger() *golog.Logger {
 
 // OptionsString returns the string representation of options.
 func (ctx *Context) OptionsString() string {
-	names := make([]string, 0, len(ctx.opts))
-	for name := range ctx.opts {
-		names = append(names, name)
-	}
-	sort.Strings(names)
+	names := ctx.optionNames()
 	str := "["
 	for i, name := range names {
 		if i > 0 {
 			str += " "
 		}
-		str += fmt.Sprintf("%s:%s", name, ctx.opts[name])
+		str += fmt.Sprintf("%s:%s", name, ctx.valueString(name))
 	}
 	return str + "]"
 }
 
 // Parse parses the given command line arguments, and returns the next argument index.
+// The arguments which are neither the registered options nor in the form of options ("KEY=VALUE", "KEY+" or "KEY-") are assigned to the positional arguments in order.
+// The argument with the escape prefix `\` is assigned to the positional argument literally without the prefix, even if it starts with "@", is "help" or is an option.
+// The arguments following the terminator "--" are not parsed, and returned by Args.
+// If the commander's Settings.Abbreviation is true, then the unique prefix of an option name (or "help") is accepted as the option name.
+// The arguments not in the form of options are not abbreviations if the command has positional arguments.
 //
 // This function returns an error in parsing.
 func (ctx *Context) Parse(args []string) (int, error) {
 	i := 0
 	for i < len(args) {
 		arg := args[i]
+		if arg == "--" {
+			ctx.args = append([]string{}, args[i+1:]...)
+			return len(args), nil
+		}
+		if strings.HasPrefix(arg, `\`) {
+			if err := ctx.parseArgument(arg[len(`\`):], true); err != nil {
+				return -1, err
+			}
+			i++
+			continue
+		}
 		if strings.HasPrefix(arg, "@") {
 			break
 		}
@@ -128,11 +339,44 @@ func (ctx *Context) Parse(args []string) (int, error) {
 		} else {
 			value = "=" + keyValue[1]
 		}
+		if ctx.opts[key] == nil && key != "" && ctx.commander.Abbreviation() && (value != "" || len(ctx.arguments) == 0) {
+			names := ctx.optionNames()
+			if value == "" {
+				names = append([]string{"help"}, names...)
+			}
+			resolved, matches := resolveAbbreviation(key, names)
+			if len(matches) > 1 {
+				return -1, ambiguousError("option", key, matches)
+			}
+			if resolved == "help" {
+				ctx.help = true
+				i++
+				continue
+			}
+			if resolved != "" {
+				key = resolved
+			}
+		}
 		opt := ctx.opts[key]
 		if opt == nil {
-			return -1, fmt.Errorf("unknown option: %s", key)
+			name, err := ctx.parseSecret(key, value)
+			if name == "" {
+				if value != "" {
+					return -1, ctx.unknownOptionError(key)
+				}
+				if err := ctx.parseArgument(arg, false); err != nil {
+					return -1, err
+				}
+				i++
+				continue
+			}
+			if err != nil {
+				return -1, fmt.Errorf("%s: %s", name, err)
+			}
+			i++
+			continue
 		}
-		if err := opt.Set(value); err != nil {
+		if err := ctx.metas[key].setOption(opt, value, Source{Kind: SourceCommandLine}); err != nil {
 			return -1, fmt.Errorf("%s: %s", key, err)
 		}
 		i++
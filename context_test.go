@@ -1,10 +1,13 @@
 package gocommander
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hiro4bbh/go-assert"
+	"github.com/hiro4bbh/go-log"
 )
 
 func TestContext(t *testing.T) {
@@ -38,6 +41,18 @@ func TestContextAddOptionErrors(t *testing.T) {
 	goassert.New(t, fmt.Errorf("illegal option name: help")).Equal(caughtPanic)
 }
 
+func TestContextHelp(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{Logger: golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "})})
+	ctx := newContext(commander, &command2{})
+	ctx.AddOption("timeout", NewOptionDuration(0), "timeout")
+	ctx.AddOption("interval", NewOptionDuration(time.Minute), "interval")
+	ctx.AddOption("since", NewOptionTime(time.Time{}), "since")
+	ctx.AddOption("until", NewOptionTime(time.Date(2018, 4, 1, 0, 0, 0, 0, time.UTC), "2006-01-02"), "until")
+	ctx.Help("cmd2")
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\n@cmd2: command 2\noptions:\n  help\tShow this help and exit\n  interval=DURATION\tinterval (default 1m0s)\n  since=TIME\tsince\n  timeout=DURATION\ttimeout\n  until=TIME\tuntil (default 2018-04-01)\n").Equal(buf.String())
+}
+
 func TestContextParse(t *testing.T) {
 	commander := New(nil)
 	ctx := newContext(commander, nil)
@@ -53,10 +68,57 @@ func TestContextParse(t *testing.T) {
 	goassert.New(t, "[opt1:true opt2:true opt3:false opt4:true]").Equal(ctx.OptionsString())
 }
 
+func TestContextParseList(t *testing.T) {
+	commander := New(nil)
+	ctx := newContext(commander, nil)
+	ctx.AddOption("include", NewOptionStringList([]string{"default"}), "include")
+	ctx.AddOption("exclude", NewOptionStringList(nil), "exclude")
+	goassert.New(t, 4).Equal(goassert.New(t).SucceedNew(ctx.Parse([]string{"include=a", "include=b,c", "exclude=x", "exclude-"})).(int))
+	goassert.New(t, []string{"default", "a", "b", "c"}).Equal(ctx.GetOption("include").(*OptionStringList).Get())
+	goassert.New(t, `[exclude:[] include:["default" "a" "b" "c"]]`).Equal(ctx.OptionsString())
+}
+
+func TestContextParseMap(t *testing.T) {
+	commander := New(nil)
+	ctx := newContext(commander, nil)
+	ctx.AddOption("set", NewOptionMap(nil), "set")
+	goassert.New(t, 3).Equal(goassert.New(t).SucceedNew(ctx.Parse([]string{"set=b:2", "set=a:1", "set=c:3"})).(int))
+	goassert.New(t, `[set:{a:"1" b:"2" c:"3"}]`).Equal(ctx.OptionsString())
+	goassert.New(t, `set: OptionMap has duplicate key: =a:0`).ExpectError(ctx.Parse([]string{"set=a:0"}))
+}
+
+func TestContextParseCounter(t *testing.T) {
+	commander := New(nil)
+	ctx := newContext(commander, nil)
+	ctx.AddOption("v", NewOptionCounter(0), "verbosity")
+	goassert.New(t, 4).Equal(goassert.New(t).SucceedNew(ctx.Parse([]string{"v", "v", "v", "v-"})).(int))
+	goassert.New(t, 2).Equal(ctx.GetOption("v").(*OptionCounter).Get())
+	goassert.New(t, 1).Equal(goassert.New(t).SucceedNew(ctx.Parse([]string{"v=3"})).(int))
+	goassert.New(t, 3).Equal(ctx.GetOption("v").(*OptionCounter).Get())
+}
+
+func TestContextParseTerminator(t *testing.T) {
+	commander := New(nil)
+	ctx := newContext(commander, nil)
+	ctx.AddOption("opt", NewOptionBool(false), "option")
+	goassert.New(t, 5).Equal(goassert.New(t).SucceedNew(ctx.Parse([]string{"opt+", "--", "@cmd", "help", "opt-"})).(int))
+	goassert.New(t, []string{"@cmd", "help", "opt-"}).Equal(ctx.Args())
+	goassert.New(t, true).Equal(ctx.GetOption("opt").(*OptionBool).Get())
+	ctx = newContext(commander, nil)
+	goassert.New(t, 1).Equal(goassert.New(t).SucceedNew(ctx.Parse([]string{"--"})).(int))
+	goassert.New(t, []string{}).Equal(ctx.Args())
+	goassert.New(t, []string(nil)).Equal(newContext(commander, nil).Args())
+}
+
 func TestContextParseErrors(t *testing.T) {
 	commander := New(nil)
 	ctx := newContext(commander, nil)
 	ctx.AddOption("opt1", NewOptionBool(false), "option 1")
-	goassert.New(t, `unknown option: opt`).ExpectError(ctx.Parse([]string{"opt"}))
+	goassert.New(t, `unknown option: opt \(did you mean opt1\? valid options: help, opt1\)`).ExpectError(ctx.Parse([]string{"opt"}))
+	goassert.New(t, `too many arguments: @opt`).ExpectError(ctx.Parse([]string{`\@opt`}))
 	goassert.New(t, `opt1: illegal OptionBool value: =X`).ExpectError(ctx.Parse([]string{"opt1=X"}))
+	ctx.AddOption("format", NewOptionEnum("json", []string{"json", "csv"}, false), "format")
+	goassert.New(t, `format: illegal OptionEnum value \(expected one of json, csv\): =xml`).ExpectError(ctx.Parse([]string{"format=xml"}))
+	ctx.AddOption("filter", NewOptionRegexp(""), "filter")
+	goassert.New(t, `filter: illegal OptionRegexp value \(missing closing \]\): =\[a`).ExpectError(ctx.Parse([]string{"filter=[a"}))
 }
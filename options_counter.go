@@ -0,0 +1,59 @@
+package gocommander
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OptionCounter is Option having a non-negative int counter variable, mainly for verbosity-style flags.
+type OptionCounter struct {
+	value int
+}
+
+// NewOptionCounter returns a new OptionCounter with the given default value.
+//
+// This function calls panic if value is negative.
+func NewOptionCounter(value int) *OptionCounter {
+	if value < 0 {
+		panic(fmt.Errorf("illegal OptionCounter default value: %d", value))
+	}
+	return &OptionCounter{value}
+}
+
+// Get returns the value.
+func (opt *OptionCounter) Get() int {
+	return opt.value
+}
+
+// Set is for interface Option.
+// "" or "+" increments the counter, "-" decrements the counter until zero, and "=$N" sets the counter to N.
+func (opt *OptionCounter) Set(str string) error {
+	switch {
+	case str == "" || str == "+":
+		opt.value++
+	case str == "-":
+		if opt.value > 0 {
+			opt.value--
+		}
+	case strings.HasPrefix(str, "="):
+		value, err := strconv.Atoi(str[len("="):])
+		if err != nil || value < 0 {
+			return fmt.Errorf("illegal OptionCounter value: %s", str)
+		}
+		opt.value = value
+	default:
+		return fmt.Errorf("illegal OptionCounter value: %s", str)
+	}
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionCounter) String() string {
+	return strconv.Itoa(opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionCounter) ValueFormat() string {
+	return "[+-]|=N"
+}
@@ -0,0 +1,39 @@
+package gocommander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionCounter(t *testing.T) {
+	opt := NewOptionCounter(0)
+	goassert.New(t, 0).Equal(opt.Get())
+	goassert.New(t, "0").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set(""))
+	goassert.New(t).SucceedWithoutError(opt.Set(""))
+	goassert.New(t).SucceedWithoutError(opt.Set("+"))
+	goassert.New(t, 3).Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Set("-"))
+	goassert.New(t, 2).Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Set("=5"))
+	goassert.New(t, 5).Equal(opt.Get())
+	goassert.New(t, "5").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=0"))
+	goassert.New(t).SucceedWithoutError(opt.Set("-"))
+	goassert.New(t, 0).Equal(opt.Get())
+	goassert.New(t, `illegal OptionCounter value: =-1`).ExpectError(opt.Set("=-1"))
+	goassert.New(t, `illegal OptionCounter value: =x`).ExpectError(opt.Set("=x"))
+	goassert.New(t, `illegal OptionCounter value: x`).ExpectError(opt.Set("x"))
+	goassert.New(t, "[+-]|=N").Equal(opt.ValueFormat())
+
+	var caughtPanic interface{}
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		NewOptionCounter(-1)
+	}()
+	goassert.New(t, fmt.Errorf("illegal OptionCounter default value: -1")).Equal(caughtPanic)
+}
@@ -0,0 +1,72 @@
+package gocommander
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OptionEnum is Option having a string variable which must be one of the choices.
+type OptionEnum struct {
+	value      string
+	choices    []string
+	ignoreCase bool
+}
+
+// NewOptionEnum returns a new OptionEnum with the given default value and choices.
+// If ignoreCase is true, then Set matches the choices case-insensitively, and stores the matched choice.
+//
+// This function calls panic if choices is empty, or value is neither the empty string nor one of choices.
+func NewOptionEnum(value string, choices []string, ignoreCase bool) *OptionEnum {
+	if len(choices) == 0 {
+		panic(fmt.Errorf("OptionEnum needs at least one choice"))
+	}
+	opt := &OptionEnum{choices: choices, ignoreCase: ignoreCase}
+	if value != "" {
+		if opt.value = opt.match(value); opt.value == "" {
+			panic(fmt.Errorf("illegal OptionEnum default value: %s", value))
+		}
+	}
+	return opt
+}
+
+func (opt *OptionEnum) match(value string) string {
+	for _, choice := range opt.choices {
+		if choice == value || (opt.ignoreCase && strings.EqualFold(choice, value)) {
+			return choice
+		}
+	}
+	return ""
+}
+
+// Choices returns the choices.
+func (opt *OptionEnum) Choices() []string {
+	return opt.choices
+}
+
+// Get returns the value.
+func (opt *OptionEnum) Get() string {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionEnum) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionEnum value: %s", str)
+	}
+	value := opt.match(str[len("="):])
+	if value == "" {
+		return fmt.Errorf("illegal OptionEnum value (expected one of %s): %s", strings.Join(opt.choices, ", "), str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionEnum) String() string {
+	return fmt.Sprintf("%q", opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionEnum) ValueFormat() string {
+	return "={" + strings.Join(opt.choices, ",") + "}"
+}
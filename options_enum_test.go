@@ -0,0 +1,43 @@
+package gocommander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionEnum(t *testing.T) {
+	opt := NewOptionEnum("json", []string{"json", "csv", "table"}, false)
+	goassert.New(t, "json").Equal(opt.Get())
+	goassert.New(t, "\"json\"").Equal(opt.String())
+	goassert.New(t, []string{"json", "csv", "table"}).Equal(opt.Choices())
+	goassert.New(t).SucceedWithoutError(opt.Set("=csv"))
+	goassert.New(t, "csv").Equal(opt.Get())
+	goassert.New(t, `illegal OptionEnum value \(expected one of json, csv, table\): =CSV`).ExpectError(opt.Set("=CSV"))
+	goassert.New(t, `illegal OptionEnum value \(expected one of json, csv, table\): =xml`).ExpectError(opt.Set("=xml"))
+	goassert.New(t, `illegal OptionEnum value: `).ExpectError(opt.Set(""))
+	goassert.New(t, "csv").Equal(opt.Get())
+	goassert.New(t, "={json,csv,table}").Equal(opt.ValueFormat())
+
+	opt = NewOptionEnum("", []string{"json", "csv", "table"}, true)
+	goassert.New(t, "").Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Set("=TaBlE"))
+	goassert.New(t, "table").Equal(opt.Get())
+
+	var caughtPanic interface{}
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		NewOptionEnum("xml", []string{"json", "csv"}, false)
+	}()
+	goassert.New(t, fmt.Errorf("illegal OptionEnum default value: xml")).Equal(caughtPanic)
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		NewOptionEnum("", nil, false)
+	}()
+	goassert.New(t, fmt.Errorf("OptionEnum needs at least one choice")).Equal(caughtPanic)
+}
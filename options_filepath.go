@@ -0,0 +1,112 @@
+package gocommander
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FilePathCheck is the check kind of OptionFilePath.
+type FilePathCheck int
+
+const (
+	// FilePathAny accepts any path.
+	FilePathAny FilePathCheck = iota
+	// FilePathExist accepts only existing paths.
+	FilePathExist
+	// FilePathFile accepts only existing regular files.
+	FilePathFile
+	// FilePathDir accepts only existing directories.
+	FilePathDir
+	// FilePathCreatable accepts only paths which are not directories and whose nearest existing ancestor is a directory.
+	FilePathCreatable
+)
+
+// Check returns an error if p does not satisfy the check.
+func (check FilePathCheck) Check(p FilePath) error {
+	switch check {
+	case FilePathAny:
+		return nil
+	case FilePathCreatable:
+		if info, err := os.Stat(string(p)); err == nil {
+			if info.IsDir() {
+				return fmt.Errorf("is a directory")
+			}
+			return nil
+		}
+		for dir := p.Dir(); ; dir = dir.Dir() {
+			if info, err := os.Stat(string(dir)); err == nil {
+				if !info.IsDir() {
+					return fmt.Errorf("cannot be created under non-directory %s", dir)
+				}
+				return nil
+			}
+			if dir == dir.Dir() {
+				return fmt.Errorf("cannot be created")
+			}
+		}
+	}
+	info, err := os.Stat(string(p))
+	if err != nil {
+		return fmt.Errorf("does not exist")
+	}
+	if check == FilePathFile && !info.Mode().IsRegular() {
+		return fmt.Errorf("is not a regular file")
+	}
+	if check == FilePathDir && !info.IsDir() {
+		return fmt.Errorf("is not a directory")
+	}
+	return nil
+}
+
+// OptionFilePath is Option having a FilePath variable.
+type OptionFilePath struct {
+	value  FilePath
+	expand bool
+	check  FilePathCheck
+}
+
+// NewOptionFilePath returns a new OptionFilePath with the given default value.
+// If expand is true, then Set expands the given path with FilePath.Expand.
+// Set checks the given path with check.
+// The default value is neither expanded nor checked.
+func NewOptionFilePath(value FilePath, expand bool, check FilePathCheck) *OptionFilePath {
+	return &OptionFilePath{value, expand, check}
+}
+
+// Get returns the value.
+func (opt *OptionFilePath) Get() FilePath {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionFilePath) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionFilePath value: %s", str)
+	}
+	value := FilePath(str[len("="):])
+	if opt.expand {
+		value = value.Expand()
+	}
+	if err := opt.check.Check(value); err != nil {
+		return fmt.Errorf("OptionFilePath %s %s: %s", value, err, str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionFilePath) String() string {
+	return fmt.Sprintf("%q", opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionFilePath) ValueFormat() string {
+	switch opt.check {
+	case FilePathFile:
+		return "=FILE"
+	case FilePathDir:
+		return "=DIR"
+	}
+	return "=PATH"
+}
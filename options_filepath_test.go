@@ -0,0 +1,50 @@
+package gocommander
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionFilePath(t *testing.T) {
+	dir := FilePath(t.TempDir())
+	file := dir.Join("file.txt")
+	goassert.New(t).SucceedWithoutError(os.WriteFile(string(file), []byte("file"), 0640))
+
+	opt := NewOptionFilePath("default", false, FilePathAny)
+	goassert.New(t, FilePath("default")).Equal(opt.Get())
+	goassert.New(t, "\"default\"").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=~/unknown"))
+	goassert.New(t, FilePath("~/unknown")).Equal(opt.Get())
+	goassert.New(t, `illegal OptionFilePath value: -`).ExpectError(opt.Set("-"))
+	goassert.New(t, "=PATH").Equal(opt.ValueFormat())
+
+	opt = NewOptionFilePath("", true, FilePathAny)
+	goassert.New(t).SucceedWithoutError(opt.Set("=~/unknown"))
+	goassert.New(t, HomeDir().Join("unknown")).Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Set("=${GOLOG_MINLEVEL}.log"))
+	goassert.New(t, FilePath("warn.log")).Equal(opt.Get())
+
+	opt = NewOptionFilePath("", false, FilePathExist)
+	goassert.New(t).SucceedWithoutError(opt.Set("=" + string(file)))
+	goassert.New(t).SucceedWithoutError(opt.Set("=" + string(dir)))
+	goassert.New(t, `OptionFilePath .*/unknown does not exist: =.*/unknown`).ExpectError(opt.Set("=" + string(dir.Join("unknown"))))
+	goassert.New(t, dir).Equal(opt.Get())
+
+	opt = NewOptionFilePath("", false, FilePathFile)
+	goassert.New(t).SucceedWithoutError(opt.Set("=" + string(file)))
+	goassert.New(t, `OptionFilePath .* is not a regular file: =.*`).ExpectError(opt.Set("=" + string(dir)))
+	goassert.New(t, "=FILE").Equal(opt.ValueFormat())
+
+	opt = NewOptionFilePath("", false, FilePathDir)
+	goassert.New(t).SucceedWithoutError(opt.Set("=" + string(dir)))
+	goassert.New(t, `OptionFilePath .*/file.txt is not a directory: =.*`).ExpectError(opt.Set("=" + string(file)))
+	goassert.New(t, "=DIR").Equal(opt.ValueFormat())
+
+	opt = NewOptionFilePath("", false, FilePathCreatable)
+	goassert.New(t).SucceedWithoutError(opt.Set("=" + string(file)))
+	goassert.New(t).SucceedWithoutError(opt.Set("=" + string(dir.Join("a/b/c.txt"))))
+	goassert.New(t, `OptionFilePath .* is a directory: =.*`).ExpectError(opt.Set("=" + string(dir)))
+	goassert.New(t, `OptionFilePath .*/file.txt/c.txt cannot be created under non-directory .*/file.txt: =.*`).ExpectError(opt.Set("=" + string(file.Join("c.txt"))))
+}
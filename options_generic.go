@@ -0,0 +1,97 @@
+package gocommander
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// typeName returns the name of the type T.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// LookupOption returns the Option of the type O with the given option name.
+//
+// This function returns an error if the option is unknown or does not have the type O.
+func LookupOption[O Option](ctx *Context, name string) (O, error) {
+	var zero O
+	opt, ok := ctx.opts[name]
+	if !ok {
+		return zero, fmt.Errorf("unknown option: %s", name)
+	}
+	o, ok := opt.(O)
+	if !ok {
+		return zero, fmt.Errorf("option %s has type %T, not %s", name, opt, typeName[O]())
+	}
+	return o, nil
+}
+
+// Lookup returns the value of the type T returned by the Get method of the Option with the given option name.
+//
+// This function returns an error if the option is unknown or does not have the Get method returning the type T.
+func Lookup[T any](ctx *Context, name string) (T, error) {
+	var zero T
+	opt, ok := ctx.opts[name]
+	if !ok {
+		return zero, fmt.Errorf("unknown option: %s", name)
+	}
+	getter, ok := opt.(interface{ Get() T })
+	if !ok {
+		return zero, fmt.Errorf("option %s of type %T does not have a value of type %s", name, opt, typeName[T]())
+	}
+	return getter.Get(), nil
+}
+
+// OptionOf is Option having a variable of the type T, which is parsed and formatted by the given functions.
+type OptionOf[T any] struct {
+	value       T
+	valueFormat string
+	parse       func(str string) (T, error)
+	format      func(value T) string
+}
+
+// NewOptionOf returns a new OptionOf with the given default value, value format (such as "=VALUE"), and parse and format functions.
+// If format is nil, then the value is formatted by fmt.Sprint.
+//
+// This function calls panic if parse is nil.
+func NewOptionOf[T any](value T, valueFormat string, parse func(str string) (T, error), format func(value T) string) *OptionOf[T] {
+	if parse == nil {
+		panic(fmt.Errorf("OptionOf[%s] needs a parse function", typeName[T]()))
+	}
+	if format == nil {
+		format = func(value T) string {
+			return fmt.Sprint(value)
+		}
+	}
+	return &OptionOf[T]{value, valueFormat, parse, format}
+}
+
+// Get returns the value.
+func (opt *OptionOf[T]) Get() T {
+	return opt.value
+}
+
+// Set is for interface Option.
+// Only the form "=$VALUE" is accepted, and VALUE is parsed by the parse function.
+func (opt *OptionOf[T]) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionOf[%s] value: %s", typeName[T](), str)
+	}
+	value, err := opt.parse(str[len("="):])
+	if err != nil {
+		return fmt.Errorf("illegal OptionOf[%s] value (%s): %s", typeName[T](), err, str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionOf[T]) String() string {
+	return opt.format(opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionOf[T]) ValueFormat() string {
+	return opt.valueFormat
+}
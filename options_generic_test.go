@@ -0,0 +1,61 @@
+package gocommander
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestLookup(t *testing.T) {
+	ctx := newContext(New(nil), nil)
+	opt := NewOptionBool(true)
+	ctx.AddOption("opt", opt, "option")
+	ctx.AddOption("threads", NewOptionInt(4), "threads")
+	goassert.New(t, opt).EqualWithoutError(LookupOption[*OptionBool](ctx, "opt"))
+	goassert.New(t, `unknown option: unknown`).ExpectError(LookupOption[*OptionBool](ctx, "unknown"))
+	goassert.New(t, `option opt has type \*gocommander.OptionBool, not \*gocommander.OptionInt`).ExpectError(LookupOption[*OptionInt](ctx, "opt"))
+	goassert.New(t, true).EqualWithoutError(Lookup[bool](ctx, "opt"))
+	goassert.New(t, 4).EqualWithoutError(Lookup[int](ctx, "threads"))
+	goassert.New(t, `unknown option: unknown`).ExpectError(Lookup[int](ctx, "unknown"))
+	goassert.New(t, `option threads of type \*gocommander.OptionInt does not have a value of type string`).ExpectError(Lookup[string](ctx, "threads"))
+}
+
+func TestOptionOf(t *testing.T) {
+	opt := NewOptionOf(int64(1), "=INT64", func(str string) (int64, error) {
+		return strconv.ParseInt(str, 10, 64)
+	}, nil)
+	goassert.New(t, int64(1)).Equal(opt.Get())
+	goassert.New(t, "1").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=42"))
+	goassert.New(t, int64(42)).Equal(opt.Get())
+	goassert.New(t, `illegal OptionOf\[int64\] value \(strconv.ParseInt: parsing "x": invalid syntax\): =x`).ExpectError(opt.Set("=x"))
+	goassert.New(t, `illegal OptionOf\[int64\] value: \+`).ExpectError(opt.Set("+"))
+	goassert.New(t, int64(42)).Equal(opt.Get())
+	goassert.New(t, "=INT64").Equal(opt.ValueFormat())
+
+	hex := NewOptionOf([]byte{}, "=HEX", func(str string) ([]byte, error) {
+		var b []byte
+		_, err := fmt.Sscanf(str, "%x", &b)
+		return b, err
+	}, func(value []byte) string {
+		return fmt.Sprintf("%x", value)
+	})
+	goassert.New(t, "").Equal(hex.String())
+	goassert.New(t).SucceedWithoutError(hex.Set("=cafe"))
+	goassert.New(t, []byte{0xca, 0xfe}).Equal(hex.Get())
+	goassert.New(t, "cafe").Equal(hex.String())
+	ctx := newContext(New(nil), nil)
+	ctx.AddOption("hex", hex, "hex")
+	goassert.New(t, []byte{0xca, 0xfe}).EqualWithoutError(Lookup[[]byte](ctx, "hex"))
+
+	var caughtPanic interface{}
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		NewOptionOf(0, "=INT", nil, nil)
+	}()
+	goassert.New(t, fmt.Errorf("OptionOf[int] needs a parse function")).Equal(caughtPanic)
+}
@@ -0,0 +1,117 @@
+package gocommander
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ListSeparator is the separator of multiple values in one occurrence of list options.
+const ListSeparator = ","
+
+// OptionStringList is Option having a string slice variable.
+//
+// Each occurrence appends the values to the slice, so the default values are kept unless they are cleared.
+type OptionStringList struct {
+	values []string
+}
+
+// NewOptionStringList returns a new OptionStringList with the given default values.
+func NewOptionStringList(values []string) *OptionStringList {
+	return &OptionStringList{append([]string{}, values...)}
+}
+
+// Get returns the values.
+func (opt *OptionStringList) Get() []string {
+	return opt.values
+}
+
+// Set is for interface Option.
+// "=$VALUE1,$VALUE2,..." appends the values, and "-" clears the values.
+func (opt *OptionStringList) Set(str string) error {
+	if str == "-" {
+		opt.values = []string{}
+	} else if strings.HasPrefix(str, "=") {
+		opt.values = append(opt.values, strings.Split(str[len("="):], ListSeparator)...)
+	} else {
+		return fmt.Errorf("illegal OptionStringList value: %s", str)
+	}
+	return nil
+}
+
+// Append appends the given value without splitting it by ListSeparator.
+func (opt *OptionStringList) Append(value string) error {
+	opt.values = append(opt.values, value)
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionStringList) String() string {
+	return fmt.Sprintf("%q", opt.values)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionStringList) ValueFormat() string {
+	return "=VALUE,..."
+}
+
+// OptionIntList is Option having an int slice variable.
+//
+// Each occurrence appends the values to the slice, so the default values are kept unless they are cleared.
+type OptionIntList struct {
+	values []int
+}
+
+// NewOptionIntList returns a new OptionIntList with the given default values.
+func NewOptionIntList(values []int) *OptionIntList {
+	return &OptionIntList{append([]int{}, values...)}
+}
+
+// Get returns the values.
+func (opt *OptionIntList) Get() []int {
+	return opt.values
+}
+
+// Set is for interface Option.
+// "=$VALUE1,$VALUE2,..." appends the values, and "-" clears the values.
+// If any value is illegal, then no value is appended.
+func (opt *OptionIntList) Set(str string) error {
+	if str == "-" {
+		opt.values = []int{}
+		return nil
+	}
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionIntList value: %s", str)
+	}
+	strs := strings.Split(str[len("="):], ListSeparator)
+	values := make([]int, 0, len(strs))
+	for _, s := range strs {
+		value, err := strconv.ParseInt(s, 0, strconv.IntSize)
+		if err != nil {
+			return fmt.Errorf("illegal OptionIntList value: %s", str)
+		}
+		values = append(values, int(value))
+	}
+	opt.values = append(opt.values, values...)
+	return nil
+}
+
+// Append appends the given value without splitting it by ListSeparator.
+func (opt *OptionIntList) Append(value string) error {
+	v, err := strconv.ParseInt(value, 0, strconv.IntSize)
+	if err != nil {
+		return fmt.Errorf("illegal OptionIntList value: %s", value)
+	}
+	opt.values = append(opt.values, int(v))
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionIntList) String() string {
+	return fmt.Sprintf("%v", opt.values)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionIntList) ValueFormat() string {
+	return "=INT,..."
+}
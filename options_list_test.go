@@ -0,0 +1,44 @@
+package gocommander
+
+import (
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionStringList(t *testing.T) {
+	opt := NewOptionStringList([]string{"default"})
+	goassert.New(t, []string{"default"}).Equal(opt.Get())
+	goassert.New(t, `["default"]`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("-"))
+	goassert.New(t, []string{}).Equal(opt.Get())
+	goassert.New(t, `[]`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=a"))
+	goassert.New(t).SucceedWithoutError(opt.Set("=b,c"))
+	goassert.New(t, []string{"a", "b", "c"}).Equal(opt.Get())
+	goassert.New(t, `["a" "b" "c"]`).Equal(opt.String())
+	goassert.New(t, `illegal OptionStringList value: `).ExpectError(opt.Set(""))
+	goassert.New(t, `illegal OptionStringList value: \+`).ExpectError(opt.Set("+"))
+	goassert.New(t, []string{"a", "b", "c"}).Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Append("d,e"))
+	goassert.New(t, []string{"a", "b", "c", "d,e"}).Equal(opt.Get())
+	goassert.New(t, "=VALUE,...").Equal(opt.ValueFormat())
+}
+
+func TestOptionIntList(t *testing.T) {
+	opt := NewOptionIntList(nil)
+	goassert.New(t, []int{}).Equal(opt.Get())
+	goassert.New(t, `[]`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=1"))
+	goassert.New(t).SucceedWithoutError(opt.Set("=2,3"))
+	goassert.New(t, []int{1, 2, 3}).Equal(opt.Get())
+	goassert.New(t, `[1 2 3]`).Equal(opt.String())
+	goassert.New(t, `illegal OptionIntList value: =4,x`).ExpectError(opt.Set("=4,x"))
+	goassert.New(t, []int{1, 2, 3}).Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Append("4"))
+	goassert.New(t, `illegal OptionIntList value: 5,6`).ExpectError(opt.Append("5,6"))
+	goassert.New(t, []int{1, 2, 3, 4}).Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Set("-"))
+	goassert.New(t, []int{}).Equal(opt.Get())
+	goassert.New(t, "=INT,...").Equal(opt.ValueFormat())
+}
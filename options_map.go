@@ -0,0 +1,92 @@
+package gocommander
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MapSeparator is the separator of a key and a value of OptionMap.
+const MapSeparator = ":"
+
+// OptionMap is Option having a string-to-string map variable.
+//
+// Each occurrence adds a key-value pair to the map.
+// The default pairs can be overwritten, but adding the same key twice is an error.
+type OptionMap struct {
+	values map[string]string
+	added  map[string]bool
+}
+
+// NewOptionMap returns a new OptionMap with the given default pairs.
+func NewOptionMap(values map[string]string) *OptionMap {
+	opt := &OptionMap{values: map[string]string{}, added: map[string]bool{}}
+	for key, value := range values {
+		opt.values[key] = value
+	}
+	return opt
+}
+
+// Get returns the map.
+func (opt *OptionMap) Get() map[string]string {
+	return opt.values
+}
+
+// Keys returns the sorted keys.
+func (opt *OptionMap) Keys() []string {
+	keys := make([]string, 0, len(opt.values))
+	for key := range opt.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Set is for interface Option.
+// "=$KEY:$VALUE" adds the pair, "=-$KEY" deletes the pair with the key, and "-" clears the map.
+func (opt *OptionMap) Set(str string) error {
+	if str == "-" {
+		opt.values, opt.added = map[string]string{}, map[string]bool{}
+		return nil
+	}
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionMap value: %s", str)
+	}
+	keyValue := strings.SplitN(str[len("="):], MapSeparator, 2)
+	if len(keyValue) < 2 {
+		if !strings.HasPrefix(keyValue[0], "-") {
+			return fmt.Errorf("illegal OptionMap value: %s", str)
+		}
+		key := keyValue[0][len("-"):]
+		if _, ok := opt.values[key]; !ok {
+			return fmt.Errorf("OptionMap has no key: %s", str)
+		}
+		delete(opt.values, key)
+		delete(opt.added, key)
+		return nil
+	}
+	key, value := keyValue[0], keyValue[1]
+	if opt.added[key] {
+		return fmt.Errorf("OptionMap has duplicate key: %s", str)
+	}
+	opt.values[key], opt.added[key] = value, true
+	return nil
+}
+
+// String is for interface Option.
+// The pairs are sorted by the keys.
+func (opt *OptionMap) String() string {
+	str := "{"
+	for i, key := range opt.Keys() {
+		if i > 0 {
+			str += " "
+		}
+		str += fmt.Sprintf("%s:%q", key, opt.values[key])
+	}
+	return str + "}"
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionMap) ValueFormat() string {
+	return "=KEY:VALUE"
+}
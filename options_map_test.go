@@ -0,0 +1,30 @@
+package gocommander
+
+import (
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionMap(t *testing.T) {
+	opt := NewOptionMap(map[string]string{"env": "dev"})
+	goassert.New(t, map[string]string{"env": "dev"}).Equal(opt.Get())
+	goassert.New(t, `{env:"dev"}`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=env:prod"))
+	goassert.New(t).SucceedWithoutError(opt.Set("=team:ml"))
+	goassert.New(t).SucceedWithoutError(opt.Set("=url:http://example.com"))
+	goassert.New(t, map[string]string{"env": "prod", "team": "ml", "url": "http://example.com"}).Equal(opt.Get())
+	goassert.New(t, []string{"env", "team", "url"}).Equal(opt.Keys())
+	goassert.New(t, `{env:"prod" team:"ml" url:"http://example.com"}`).Equal(opt.String())
+	goassert.New(t, `OptionMap has duplicate key: =env:test`).ExpectError(opt.Set("=env:test"))
+	goassert.New(t).SucceedWithoutError(opt.Set("=-env"))
+	goassert.New(t).SucceedWithoutError(opt.Set("=env:test"))
+	goassert.New(t, `{env:"test" team:"ml" url:"http://example.com"}`).Equal(opt.String())
+	goassert.New(t, `OptionMap has no key: =-unknown`).ExpectError(opt.Set("=-unknown"))
+	goassert.New(t, `illegal OptionMap value: =env`).ExpectError(opt.Set("=env"))
+	goassert.New(t, `illegal OptionMap value: \+`).ExpectError(opt.Set("+"))
+	goassert.New(t).SucceedWithoutError(opt.Set("-"))
+	goassert.New(t, `{}`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=env:dev"))
+	goassert.New(t, "=KEY:VALUE").Equal(opt.ValueFormat())
+}
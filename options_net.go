@@ -0,0 +1,242 @@
+package gocommander
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OptionURL is Option having an absolute *url.URL variable.
+type OptionURL struct {
+	value   *url.URL
+	schemes []string
+}
+
+// NewOptionURL returns a new OptionURL with the given default raw URL and the allowed schemes.
+// If rawurl is the empty string, then the default value is nil.
+// If no scheme is given, then any scheme is allowed.
+//
+// This function calls panic if rawurl is illegal.
+func NewOptionURL(rawurl string, schemes ...string) *OptionURL {
+	opt := &OptionURL{schemes: schemes}
+	if rawurl != "" {
+		if err := opt.Set("=" + rawurl); err != nil {
+			panic(err)
+		}
+	}
+	return opt
+}
+
+// Get returns the value.
+func (opt *OptionURL) Get() *url.URL {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionURL) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionURL value: %s", str)
+	}
+	value, err := url.Parse(str[len("="):])
+	if err != nil {
+		return fmt.Errorf("illegal OptionURL value (%s): %s", err.(*url.Error).Err, str)
+	}
+	if !value.IsAbs() {
+		return fmt.Errorf("illegal OptionURL value (missing scheme): %s", str)
+	}
+	if len(opt.schemes) > 0 {
+		found := false
+		for _, scheme := range opt.schemes {
+			if strings.EqualFold(scheme, value.Scheme) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("illegal OptionURL value (scheme must be one of %s): %s", strings.Join(opt.schemes, ", "), str)
+		}
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionURL) String() string {
+	if opt.value == nil {
+		return `""`
+	}
+	return fmt.Sprintf("%q", opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionURL) ValueFormat() string {
+	return "=URL"
+}
+
+// OptionHostPort is Option having a "$HOST:$PORT" address variable.
+// HOST can be empty for listening on all addresses.
+type OptionHostPort struct {
+	host string
+	port int
+}
+
+// NewOptionHostPort returns a new OptionHostPort with the given default address.
+// If hostport is the empty string, then the default value is the empty string.
+//
+// This function calls panic if hostport is illegal.
+func NewOptionHostPort(hostport string) *OptionHostPort {
+	opt := &OptionHostPort{port: -1}
+	if hostport != "" {
+		if err := opt.Set("=" + hostport); err != nil {
+			panic(err)
+		}
+	}
+	return opt
+}
+
+// Get returns the address.
+func (opt *OptionHostPort) Get() string {
+	if opt.port < 0 {
+		return ""
+	}
+	return net.JoinHostPort(opt.host, strconv.Itoa(opt.port))
+}
+
+// Host returns the host part of the address.
+func (opt *OptionHostPort) Host() string {
+	return opt.host
+}
+
+// Port returns the port part of the address, or -1 if the address is empty.
+func (opt *OptionHostPort) Port() int {
+	return opt.port
+}
+
+// Set is for interface Option.
+func (opt *OptionHostPort) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionHostPort value: %s", str)
+	}
+	host, portStr, err := net.SplitHostPort(str[len("="):])
+	if err != nil {
+		return fmt.Errorf("illegal OptionHostPort value (%s): %s", err.(*net.AddrError).Err, str)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return fmt.Errorf("illegal OptionHostPort value (port must be in [0, 65535]): %s", str)
+	}
+	opt.host, opt.port = host, int(port)
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionHostPort) String() string {
+	return fmt.Sprintf("%q", opt.Get())
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionHostPort) ValueFormat() string {
+	return "=HOST:PORT"
+}
+
+// OptionIP is Option having a net.IP variable.
+type OptionIP struct {
+	value net.IP
+}
+
+// NewOptionIP returns a new OptionIP with the given default IP address.
+// If ip is the empty string, then the default value is nil.
+//
+// This function calls panic if ip is illegal.
+func NewOptionIP(ip string) *OptionIP {
+	opt := &OptionIP{}
+	if ip != "" {
+		if err := opt.Set("=" + ip); err != nil {
+			panic(err)
+		}
+	}
+	return opt
+}
+
+// Get returns the value.
+func (opt *OptionIP) Get() net.IP {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionIP) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionIP value: %s", str)
+	}
+	value := net.ParseIP(str[len("="):])
+	if value == nil {
+		return fmt.Errorf("illegal OptionIP value: %s", str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionIP) String() string {
+	if opt.value == nil {
+		return `""`
+	}
+	return fmt.Sprintf("%q", opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionIP) ValueFormat() string {
+	return "=IP"
+}
+
+// OptionCIDR is Option having a *net.IPNet variable.
+type OptionCIDR struct {
+	value *net.IPNet
+}
+
+// NewOptionCIDR returns a new OptionCIDR with the given default CIDR notation.
+// If cidr is the empty string, then the default value is nil.
+//
+// This function calls panic if cidr is illegal.
+func NewOptionCIDR(cidr string) *OptionCIDR {
+	opt := &OptionCIDR{}
+	if cidr != "" {
+		if err := opt.Set("=" + cidr); err != nil {
+			panic(err)
+		}
+	}
+	return opt
+}
+
+// Get returns the value.
+func (opt *OptionCIDR) Get() *net.IPNet {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionCIDR) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionCIDR value: %s", str)
+	}
+	_, value, err := net.ParseCIDR(str[len("="):])
+	if err != nil {
+		return fmt.Errorf("illegal OptionCIDR value: %s", str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionCIDR) String() string {
+	if opt.value == nil {
+		return `""`
+	}
+	return fmt.Sprintf("%q", opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionCIDR) ValueFormat() string {
+	return "=CIDR"
+}
@@ -0,0 +1,86 @@
+package gocommander
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionURL(t *testing.T) {
+	opt := NewOptionURL("")
+	goassert.New(t, true).Equal(opt.Get() == nil)
+	goassert.New(t, `""`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=ftp://example.com/a.gz"))
+	goassert.New(t, "ftp").Equal(opt.Get().Scheme)
+	goassert.New(t, `"ftp://example.com/a.gz"`).Equal(opt.String())
+	goassert.New(t, `illegal OptionURL value \(missing scheme\): =example.com`).ExpectError(opt.Set("=example.com"))
+	goassert.New(t, `illegal OptionURL value \(invalid URL escape "%zz"\): =http://example.com/%zz`).ExpectError(opt.Set("=http://example.com/%zz"))
+	goassert.New(t, `illegal OptionURL value: `).ExpectError(opt.Set(""))
+	goassert.New(t, "=URL").Equal(opt.ValueFormat())
+
+	opt = NewOptionURL("https://example.com/", "http", "https")
+	goassert.New(t, "example.com").Equal(opt.Get().Host)
+	goassert.New(t).SucceedWithoutError(opt.Set("=HTTP://example.org/"))
+	goassert.New(t, `illegal OptionURL value \(scheme must be one of http, https\): =ftp://example.com/`).ExpectError(opt.Set("=ftp://example.com/"))
+	goassert.New(t, "example.org").Equal(opt.Get().Host)
+
+	var caughtPanic interface{}
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		NewOptionURL("ftp://example.com/", "http")
+	}()
+	goassert.New(t, fmt.Errorf("illegal OptionURL value (scheme must be one of http): =ftp://example.com/")).Equal(caughtPanic)
+}
+
+func TestOptionHostPort(t *testing.T) {
+	opt := NewOptionHostPort("")
+	goassert.New(t, "").Equal(opt.Get())
+	goassert.New(t, -1).Equal(opt.Port())
+	goassert.New(t, `""`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=:8080"))
+	goassert.New(t, ":8080").Equal(opt.Get())
+	goassert.New(t, "").Equal(opt.Host())
+	goassert.New(t, 8080).Equal(opt.Port())
+	goassert.New(t).SucceedWithoutError(opt.Set("=[::1]:443"))
+	goassert.New(t, "[::1]:443").Equal(opt.Get())
+	goassert.New(t, "::1").Equal(opt.Host())
+	goassert.New(t, `"[::1]:443"`).Equal(opt.String())
+	goassert.New(t, `illegal OptionHostPort value \(missing port in address\): =localhost`).ExpectError(opt.Set("=localhost"))
+	goassert.New(t, `illegal OptionHostPort value \(port must be in \[0, 65535\]\): =localhost:65536`).ExpectError(opt.Set("=localhost:65536"))
+	goassert.New(t, `illegal OptionHostPort value \(port must be in \[0, 65535\]\): =localhost:http`).ExpectError(opt.Set("=localhost:http"))
+	goassert.New(t, "[::1]:443").Equal(opt.Get())
+	goassert.New(t, "=HOST:PORT").Equal(opt.ValueFormat())
+
+	opt = NewOptionHostPort("localhost:80")
+	goassert.New(t, "localhost").Equal(opt.Host())
+	goassert.New(t, 80).Equal(opt.Port())
+}
+
+func TestOptionIP(t *testing.T) {
+	opt := NewOptionIP("")
+	goassert.New(t, net.IP(nil)).Equal(opt.Get())
+	goassert.New(t, `""`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=192.168.0.1"))
+	goassert.New(t, true).Equal(opt.Get().Equal(net.IPv4(192, 168, 0, 1)))
+	goassert.New(t, `"192.168.0.1"`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=::1"))
+	goassert.New(t, true).Equal(opt.Get().Equal(net.IPv6loopback))
+	goassert.New(t, `illegal OptionIP value: =192.168.0.256`).ExpectError(opt.Set("=192.168.0.256"))
+	goassert.New(t, "=IP").Equal(opt.ValueFormat())
+}
+
+func TestOptionCIDR(t *testing.T) {
+	opt := NewOptionCIDR("10.0.0.0/8")
+	goassert.New(t, `"10.0.0.0/8"`).Equal(opt.String())
+	goassert.New(t, true).Equal(opt.Get().Contains(net.IPv4(10, 1, 2, 3)))
+	goassert.New(t).SucceedWithoutError(opt.Set("=192.168.1.10/24"))
+	goassert.New(t, `"192.168.1.0/24"`).Equal(opt.String())
+	goassert.New(t, `illegal OptionCIDR value: =192.168.1.10`).ExpectError(opt.Set("=192.168.1.10"))
+	goassert.New(t, `illegal OptionCIDR value: =192.168.1.0/33`).ExpectError(opt.Set("=192.168.1.0/33"))
+	goassert.New(t, "=CIDR").Equal(opt.ValueFormat())
+	goassert.New(t, `""`).Equal(NewOptionCIDR("").String())
+}
@@ -0,0 +1,161 @@
+package gocommander
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// OptionInt is Option having an int variable in the range [min, max].
+type OptionInt struct {
+	value, min, max int
+}
+
+// NewOptionInt returns a new OptionInt with the given default value.
+func NewOptionInt(value int) *OptionInt {
+	return NewOptionIntRange(value, math.MinInt, math.MaxInt)
+}
+
+// NewOptionIntRange returns a new OptionInt with the given default value and the range [min, max].
+//
+// This function calls panic if min > max or value is out of the range.
+func NewOptionIntRange(value, min, max int) *OptionInt {
+	if min > max || value < min || value > max {
+		panic(fmt.Errorf("illegal OptionInt range [%d, %d] with default value %d", min, max, value))
+	}
+	return &OptionInt{value, min, max}
+}
+
+// Get returns the value.
+func (opt *OptionInt) Get() int {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionInt) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionInt value: %s", str)
+	}
+	value, err := strconv.ParseInt(str[len("="):], 0, strconv.IntSize)
+	if err != nil {
+		return fmt.Errorf("illegal OptionInt value: %s", str)
+	}
+	if int(value) < opt.min || int(value) > opt.max {
+		return fmt.Errorf("OptionInt value out of range [%d, %d]: %s", opt.min, opt.max, str)
+	}
+	opt.value = int(value)
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionInt) String() string {
+	return strconv.Itoa(opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionInt) ValueFormat() string {
+	return "=INT"
+}
+
+// OptionUint is Option having an uint variable in the range [min, max].
+type OptionUint struct {
+	value, min, max uint
+}
+
+// NewOptionUint returns a new OptionUint with the given default value.
+func NewOptionUint(value uint) *OptionUint {
+	return NewOptionUintRange(value, 0, math.MaxUint)
+}
+
+// NewOptionUintRange returns a new OptionUint with the given default value and the range [min, max].
+//
+// This function calls panic if min > max or value is out of the range.
+func NewOptionUintRange(value, min, max uint) *OptionUint {
+	if min > max || value < min || value > max {
+		panic(fmt.Errorf("illegal OptionUint range [%d, %d] with default value %d", min, max, value))
+	}
+	return &OptionUint{value, min, max}
+}
+
+// Get returns the value.
+func (opt *OptionUint) Get() uint {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionUint) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionUint value: %s", str)
+	}
+	value, err := strconv.ParseUint(str[len("="):], 0, strconv.IntSize)
+	if err != nil {
+		return fmt.Errorf("illegal OptionUint value: %s", str)
+	}
+	if uint(value) < opt.min || uint(value) > opt.max {
+		return fmt.Errorf("OptionUint value out of range [%d, %d]: %s", opt.min, opt.max, str)
+	}
+	opt.value = uint(value)
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionUint) String() string {
+	return strconv.FormatUint(uint64(opt.value), 10)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionUint) ValueFormat() string {
+	return "=UINT"
+}
+
+// OptionFloat is Option having a float64 variable in the range [min, max].
+type OptionFloat struct {
+	value, min, max float64
+}
+
+// NewOptionFloat returns a new OptionFloat with the given default value.
+func NewOptionFloat(value float64) *OptionFloat {
+	return NewOptionFloatRange(value, math.Inf(-1), math.Inf(+1))
+}
+
+// NewOptionFloatRange returns a new OptionFloat with the given default value and the range [min, max].
+//
+// This function calls panic if min > max or value is out of the range (NaN is always out of the range).
+func NewOptionFloatRange(value, min, max float64) *OptionFloat {
+	if !(min <= max && min <= value && value <= max) {
+		panic(fmt.Errorf("illegal OptionFloat range [%g, %g] with default value %g", min, max, value))
+	}
+	return &OptionFloat{value, min, max}
+}
+
+// Get returns the value.
+func (opt *OptionFloat) Get() float64 {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionFloat) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionFloat value: %s", str)
+	}
+	value, err := strconv.ParseFloat(str[len("="):], 64)
+	if err != nil {
+		return fmt.Errorf("illegal OptionFloat value: %s", str)
+	}
+	if !(opt.min <= value && value <= opt.max) {
+		return fmt.Errorf("OptionFloat value out of range [%g, %g]: %s", opt.min, opt.max, str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionFloat) String() string {
+	return strconv.FormatFloat(opt.value, 'g', -1, 64)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionFloat) ValueFormat() string {
+	return "=FLOAT"
+}
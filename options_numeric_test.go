@@ -0,0 +1,70 @@
+package gocommander
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionInt(t *testing.T) {
+	opt := NewOptionInt(-1)
+	goassert.New(t, -1).Equal(opt.Get())
+	goassert.New(t, "-1").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=42"))
+	goassert.New(t, 42).Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Set("=0x10"))
+	goassert.New(t, 16).Equal(opt.Get())
+	goassert.New(t, `illegal OptionInt value: `).ExpectError(opt.Set(""))
+	goassert.New(t, `illegal OptionInt value: \+`).ExpectError(opt.Set("+"))
+	goassert.New(t, `illegal OptionInt value: =1.5`).ExpectError(opt.Set("=1.5"))
+	goassert.New(t, 16).Equal(opt.Get())
+	goassert.New(t, "=INT").Equal(opt.ValueFormat())
+
+	opt = NewOptionIntRange(4, 1, 8)
+	goassert.New(t).SucceedWithoutError(opt.Set("=8"))
+	goassert.New(t, 8).Equal(opt.Get())
+	goassert.New(t, `OptionInt value out of range \[1, 8\]: =9`).ExpectError(opt.Set("=9"))
+	goassert.New(t, `OptionInt value out of range \[1, 8\]: =0`).ExpectError(opt.Set("=0"))
+	goassert.New(t, 8).Equal(opt.Get())
+
+	var caughtPanic interface{}
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		NewOptionIntRange(0, 1, 8)
+	}()
+	goassert.New(t, fmt.Errorf("illegal OptionInt range [1, 8] with default value 0")).Equal(caughtPanic)
+}
+
+func TestOptionUint(t *testing.T) {
+	opt := NewOptionUint(3)
+	goassert.New(t, uint(3)).Equal(opt.Get())
+	goassert.New(t, "3").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=42"))
+	goassert.New(t, uint(42)).Equal(opt.Get())
+	goassert.New(t, `illegal OptionUint value: =-1`).ExpectError(opt.Set("=-1"))
+	goassert.New(t, `illegal OptionUint value: -`).ExpectError(opt.Set("-"))
+	goassert.New(t, "=UINT").Equal(opt.ValueFormat())
+
+	opt = NewOptionUintRange(1, 1, 65535)
+	goassert.New(t, `OptionUint value out of range \[1, 65535\]: =65536`).ExpectError(opt.Set("=65536"))
+	goassert.New(t, uint(1)).Equal(opt.Get())
+}
+
+func TestOptionFloat(t *testing.T) {
+	opt := NewOptionFloat(0.5)
+	goassert.New(t, 0.5).Equal(opt.Get())
+	goassert.New(t, "0.5").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=1e-3"))
+	goassert.New(t, 1e-3).Equal(opt.Get())
+	goassert.New(t, "0.001").Equal(opt.String())
+	goassert.New(t, `illegal OptionFloat value: =x`).ExpectError(opt.Set("=x"))
+	goassert.New(t, "=FLOAT").Equal(opt.ValueFormat())
+
+	opt = NewOptionFloatRange(0.1, 0.0, 1.0)
+	goassert.New(t, `OptionFloat value out of range \[0, 1\]: =1.5`).ExpectError(opt.Set("=1.5"))
+	goassert.New(t, `OptionFloat value out of range \[0, 1\]: =NaN`).ExpectError(opt.Set("=NaN"))
+	goassert.New(t, 0.1).Equal(opt.Get())
+}
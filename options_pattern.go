@@ -0,0 +1,112 @@
+package gocommander
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// OptionRegexp is Option having a compiled *regexp.Regexp variable.
+type OptionRegexp struct {
+	value *regexp.Regexp
+}
+
+// NewOptionRegexp returns a new OptionRegexp with the given default pattern.
+// If pattern is the empty string, then the default value is nil.
+//
+// This function calls panic if pattern cannot be compiled.
+func NewOptionRegexp(pattern string) *OptionRegexp {
+	opt := &OptionRegexp{}
+	if pattern != "" {
+		opt.value = regexp.MustCompile(pattern)
+	}
+	return opt
+}
+
+// Get returns the value.
+func (opt *OptionRegexp) Get() *regexp.Regexp {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionRegexp) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionRegexp value: %s", str)
+	}
+	value, err := regexp.Compile(str[len("="):])
+	if err != nil {
+		return fmt.Errorf("illegal OptionRegexp value (%s): %s", err.(*syntax.Error).Code, str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionRegexp) String() string {
+	if opt.value == nil {
+		return `""`
+	}
+	return fmt.Sprintf("%q", opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionRegexp) ValueFormat() string {
+	return "=REGEXP"
+}
+
+// OptionGlob is Option having a pattern variable for filepath.Match.
+type OptionGlob struct {
+	value string
+}
+
+// NewOptionGlob returns a new OptionGlob with the given default pattern.
+//
+// This function calls panic if pattern is illegal.
+func NewOptionGlob(pattern string) *OptionGlob {
+	opt := &OptionGlob{}
+	if err := opt.Set("=" + pattern); err != nil {
+		panic(err)
+	}
+	return opt
+}
+
+// Get returns the pattern.
+func (opt *OptionGlob) Get() string {
+	return opt.value
+}
+
+// Match reports whether name matches the pattern by filepath.Match.
+// The empty pattern matches any name.
+func (opt *OptionGlob) Match(name string) bool {
+	if opt.value == "" {
+		return true
+	}
+	// The pattern is validated in Set, so filepath.Match never returns an error.
+	matched, _ := filepath.Match(opt.value, name)
+	return matched
+}
+
+// Set is for interface Option.
+func (opt *OptionGlob) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionGlob value: %s", str)
+	}
+	value := str[len("="):]
+	if _, err := filepath.Match(value, ""); err != nil {
+		return fmt.Errorf("illegal OptionGlob value (%s): %s", err, str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionGlob) String() string {
+	return fmt.Sprintf("%q", opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionGlob) ValueFormat() string {
+	return "=GLOB"
+}
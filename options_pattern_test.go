@@ -0,0 +1,48 @@
+package gocommander
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionRegexp(t *testing.T) {
+	opt := NewOptionRegexp("")
+	goassert.New(t, (*regexp.Regexp)(nil)).Equal(opt.Get())
+	goassert.New(t, `""`).Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=^a+b$"))
+	goassert.New(t, true).Equal(opt.Get().MatchString("aab"))
+	goassert.New(t, false).Equal(opt.Get().MatchString("abb"))
+	goassert.New(t, `"^a+b$"`).Equal(opt.String())
+	goassert.New(t, `illegal OptionRegexp value \(missing closing \)\): =\(a`).ExpectError(opt.Set("=(a"))
+	goassert.New(t, `illegal OptionRegexp value: -`).ExpectError(opt.Set("-"))
+	goassert.New(t, "^a+b$").Equal(opt.Get().String())
+	goassert.New(t, "=REGEXP").Equal(opt.ValueFormat())
+
+	goassert.New(t, `"\\d+"`).Equal(NewOptionRegexp(`\d+`).String())
+}
+
+func TestOptionGlob(t *testing.T) {
+	opt := NewOptionGlob("")
+	goassert.New(t, "").Equal(opt.Get())
+	goassert.New(t, true).Equal(opt.Match("anything"))
+	goassert.New(t).SucceedWithoutError(opt.Set("=*.csv"))
+	goassert.New(t, true).Equal(opt.Match("a.csv"))
+	goassert.New(t, false).Equal(opt.Match("a.json"))
+	goassert.New(t, false).Equal(opt.Match("dir/a.csv"))
+	goassert.New(t, `"*.csv"`).Equal(opt.String())
+	goassert.New(t, `illegal OptionGlob value \(syntax error in pattern\): =\[a`).ExpectError(opt.Set("=[a"))
+	goassert.New(t, "*.csv").Equal(opt.Get())
+	goassert.New(t, "=GLOB").Equal(opt.ValueFormat())
+
+	var caughtPanic interface{}
+	func() {
+		defer func() {
+			caughtPanic = recover()
+		}()
+		NewOptionGlob("[a")
+	}()
+	goassert.New(t, fmt.Errorf("illegal OptionGlob value (syntax error in pattern): =[a")).Equal(caughtPanic)
+}
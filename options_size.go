@@ -0,0 +1,120 @@
+package gocommander
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// sizeUnitPrefixes is the unit prefixes in order of magnitude.
+const sizeUnitPrefixes = "KMGTPE"
+
+// ParseSize parses str as a byte size, and returns it.
+// str has the form "$NUMBER$UNIT", where NUMBER can have a fraction and UNIT is case-insensitive.
+// UNIT is "" or "B" (bytes), SI units "KB", "MB", ..., "EB" (powers of 1000), or IEC units "K", "KiB", "M", "MiB", ..., "E", "EiB" (powers of 1024).
+// The resulting size is rounded to the nearest integer.
+//
+// This function returns an error if str is illegal, or the size is negative or overflows int64.
+func ParseSize(str string) (int64, error) {
+	i := len(str)
+	for i > 0 && strings.IndexByte("0123456789.", str[i-1]) < 0 {
+		i--
+	}
+	number, unit := str[:i], strings.ToUpper(str[i:])
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("illegal size: %s", str)
+	}
+	multiplier := 1.0
+	if unit != "" && unit != "B" {
+		exponent := strings.IndexByte(sizeUnitPrefixes, unit[0]) + 1
+		if exponent == 0 {
+			return 0, fmt.Errorf("illegal size unit: %s", str)
+		}
+		switch unit[1:] {
+		case "", "I", "IB":
+			multiplier = math.Pow(1024, float64(exponent))
+		case "B":
+			multiplier = math.Pow(1000, float64(exponent))
+		default:
+			return 0, fmt.Errorf("illegal size unit: %s", str)
+		}
+	}
+	size := math.Round(value * multiplier)
+	if size < 0 || size >= math.MaxInt64 {
+		return 0, fmt.Errorf("size out of range: %s", str)
+	}
+	return int64(size), nil
+}
+
+// FormatSize returns the human-readable representation of the byte size.
+// This function uses the largest IEC or SI unit with which the representation has at most two decimal places, preferring IEC units.
+// The result is always parsed by ParseSize to the same size.
+func FormatSize(size int64) string {
+	for exponent := len(sizeUnitPrefixes); exponent >= 1; exponent-- {
+		prefix := sizeUnitPrefixes[exponent-1 : exponent]
+		for _, u := range []struct {
+			name string
+			base float64
+		}{{prefix + "iB", 1024}, {prefix + "B", 1000}} {
+			value := float64(size) / math.Pow(u.base, float64(exponent))
+			if value < 1 {
+				continue
+			}
+			str := strconv.FormatFloat(value, 'f', -1, 64)
+			if dot := strings.IndexByte(str, '.'); dot >= 0 && len(str)-dot-1 > 2 {
+				continue
+			}
+			if parsed, err := ParseSize(str + u.name); err == nil && parsed == size {
+				return str + u.name
+			}
+		}
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// OptionSize is Option having a byte size variable.
+type OptionSize struct {
+	value int64
+}
+
+// NewOptionSize returns a new OptionSize with the given default byte size.
+//
+// This function calls panic if value is negative.
+func NewOptionSize(value int64) *OptionSize {
+	if value < 0 {
+		panic(fmt.Errorf("illegal OptionSize default value: %d", value))
+	}
+	return &OptionSize{value}
+}
+
+// Get returns the byte size.
+func (opt *OptionSize) Get() int64 {
+	return opt.value
+}
+
+// Set is for interface Option.
+// The value is parsed by ParseSize.
+func (opt *OptionSize) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionSize value: %s", str)
+	}
+	value, err := ParseSize(str[len("="):])
+	if err != nil {
+		return fmt.Errorf("illegal OptionSize value (%s): %s", err, str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+// The byte size is formatted by FormatSize.
+func (opt *OptionSize) String() string {
+	return FormatSize(opt.value)
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionSize) ValueFormat() string {
+	return "=SIZE"
+}
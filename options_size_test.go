@@ -0,0 +1,53 @@
+package gocommander
+
+import (
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestParseSize(t *testing.T) {
+	goassert.New(t, int64(0)).EqualWithoutError(ParseSize("0"))
+	goassert.New(t, int64(100)).EqualWithoutError(ParseSize("100"))
+	goassert.New(t, int64(100)).EqualWithoutError(ParseSize("100B"))
+	goassert.New(t, int64(512*1024)).EqualWithoutError(ParseSize("512K"))
+	goassert.New(t, int64(512*1024)).EqualWithoutError(ParseSize("512k"))
+	goassert.New(t, int64(512*1024)).EqualWithoutError(ParseSize("512KiB"))
+	goassert.New(t, int64(512*1024)).EqualWithoutError(ParseSize("512Ki"))
+	goassert.New(t, int64(512*1000)).EqualWithoutError(ParseSize("512KB"))
+	goassert.New(t, int64(1536*1024*1024)).EqualWithoutError(ParseSize("1.5GiB"))
+	goassert.New(t, int64(10*1000*1000)).EqualWithoutError(ParseSize("10MB"))
+	goassert.New(t, int64(3*1024*1024*1024*1024)).EqualWithoutError(ParseSize("3T"))
+	goassert.New(t, int64(2*1000*1000*1000*1000*1000)).EqualWithoutError(ParseSize("2PB"))
+	goassert.New(t, `illegal size: `).ExpectError(ParseSize(""))
+	goassert.New(t, `illegal size: MB`).ExpectError(ParseSize("MB"))
+	goassert.New(t, `size out of range: -1K`).ExpectError(ParseSize("-1K"))
+	goassert.New(t, `illegal size unit: 1XB`).ExpectError(ParseSize("1XB"))
+	goassert.New(t, `illegal size unit: 1KiBB`).ExpectError(ParseSize("1KiBB"))
+	goassert.New(t, `size out of range: 8EiB`).ExpectError(ParseSize("8EiB"))
+}
+
+func TestFormatSize(t *testing.T) {
+	goassert.New(t, "0").Equal(FormatSize(0))
+	goassert.New(t, "100").Equal(FormatSize(100))
+	goassert.New(t, "1KiB").Equal(FormatSize(1024))
+	goassert.New(t, "1KB").Equal(FormatSize(1000))
+	goassert.New(t, "512KiB").Equal(FormatSize(512 * 1024))
+	goassert.New(t, "1.5GiB").Equal(FormatSize(1536 * 1024 * 1024))
+	goassert.New(t, "10MB").Equal(FormatSize(10 * 1000 * 1000))
+	goassert.New(t, "1025").Equal(FormatSize(1025))
+	goassert.New(t, "1.23MB").Equal(FormatSize(1230000))
+}
+
+func TestOptionSize(t *testing.T) {
+	opt := NewOptionSize(64 * 1024 * 1024)
+	goassert.New(t, int64(64*1024*1024)).Equal(opt.Get())
+	goassert.New(t, "64MiB").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=1.5GiB"))
+	goassert.New(t, int64(1536*1024*1024)).Equal(opt.Get())
+	goassert.New(t, "1.5GiB").Equal(opt.String())
+	goassert.New(t, `illegal OptionSize value \(illegal size unit: 1ZB\): =1ZB`).ExpectError(opt.Set("=1ZB"))
+	goassert.New(t, `illegal OptionSize value: \+`).ExpectError(opt.Set("+"))
+	goassert.New(t, int64(1536*1024*1024)).Equal(opt.Get())
+	goassert.New(t, "=SIZE").Equal(opt.ValueFormat())
+}
@@ -0,0 +1,99 @@
+package gocommander
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultTimeLayouts is the default layouts of OptionTime (RFC3339 and date-only).
+var DefaultTimeLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// OptionDuration is Option having a time.Duration variable.
+type OptionDuration struct {
+	value time.Duration
+}
+
+// NewOptionDuration returns a new OptionDuration with the given default value.
+func NewOptionDuration(value time.Duration) *OptionDuration {
+	return &OptionDuration{value}
+}
+
+// Get returns the value.
+func (opt *OptionDuration) Get() time.Duration {
+	return opt.value
+}
+
+// Set is for interface Option.
+// The value is parsed by time.ParseDuration.
+func (opt *OptionDuration) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionDuration value: %s", str)
+	}
+	value, err := time.ParseDuration(str[len("="):])
+	if err != nil {
+		return fmt.Errorf("illegal OptionDuration value: %s", str)
+	}
+	opt.value = value
+	return nil
+}
+
+// String is for interface Option.
+func (opt *OptionDuration) String() string {
+	return opt.value.String()
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionDuration) ValueFormat() string {
+	return "=DURATION"
+}
+
+// OptionTime is Option having a time.Time variable.
+type OptionTime struct {
+	value   time.Time
+	layouts []string
+}
+
+// NewOptionTime returns a new OptionTime with the given default value and layouts.
+// If no layout is given, then DefaultTimeLayouts is used.
+//
+// Set tries the layouts in order, and String uses the first layout.
+func NewOptionTime(value time.Time, layouts ...string) *OptionTime {
+	if len(layouts) == 0 {
+		layouts = DefaultTimeLayouts
+	}
+	return &OptionTime{value, layouts}
+}
+
+// Get returns the value.
+func (opt *OptionTime) Get() time.Time {
+	return opt.value
+}
+
+// Set is for interface Option.
+func (opt *OptionTime) Set(str string) error {
+	if !strings.HasPrefix(str, "=") {
+		return fmt.Errorf("illegal OptionTime value: %s", str)
+	}
+	for _, layout := range opt.layouts {
+		if value, err := time.Parse(layout, str[len("="):]); err == nil {
+			opt.value = value
+			return nil
+		}
+	}
+	return fmt.Errorf("illegal OptionTime value (expected layout %s): %s", strings.Join(opt.layouts, " or "), str)
+}
+
+// String is for interface Option.
+// The zero time is represented as the empty string.
+func (opt *OptionTime) String() string {
+	if opt.value.IsZero() {
+		return ""
+	}
+	return opt.value.Format(opt.layouts[0])
+}
+
+// ValueFormat is for interface Option.
+func (opt *OptionTime) ValueFormat() string {
+	return "=TIME"
+}
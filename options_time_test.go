@@ -0,0 +1,38 @@
+package gocommander
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestOptionDuration(t *testing.T) {
+	opt := NewOptionDuration(30 * time.Second)
+	goassert.New(t, 30*time.Second).Equal(opt.Get())
+	goassert.New(t, "30s").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=1h30m"))
+	goassert.New(t, 90*time.Minute).Equal(opt.Get())
+	goassert.New(t, "1h30m0s").Equal(opt.String())
+	goassert.New(t, `illegal OptionDuration value: =10`).ExpectError(opt.Set("=10"))
+	goassert.New(t, `illegal OptionDuration value: `).ExpectError(opt.Set(""))
+	goassert.New(t, 90*time.Minute).Equal(opt.Get())
+	goassert.New(t, "=DURATION").Equal(opt.ValueFormat())
+}
+
+func TestOptionTime(t *testing.T) {
+	opt := NewOptionTime(time.Time{})
+	goassert.New(t, time.Time{}).Equal(opt.Get())
+	goassert.New(t, "").Equal(opt.String())
+	goassert.New(t).SucceedWithoutError(opt.Set("=2018-01-02T03:04:05Z"))
+	goassert.New(t, time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)).Equal(opt.Get())
+	goassert.New(t).SucceedWithoutError(opt.Set("=2018-01-02"))
+	goassert.New(t, time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)).Equal(opt.Get())
+	goassert.New(t, "2018-01-02T00:00:00Z").Equal(opt.String())
+	goassert.New(t, `illegal OptionTime value \(expected layout 2006-01-02T15:04:05Z07:00 or 2006-01-02\): =2018/01/02`).ExpectError(opt.Set("=2018/01/02"))
+	goassert.New(t, "=TIME").Equal(opt.ValueFormat())
+
+	opt = NewOptionTime(time.Date(2018, 4, 1, 0, 0, 0, 0, time.UTC), "2006-01-02")
+	goassert.New(t, "2018-04-01").Equal(opt.String())
+	goassert.New(t, `illegal OptionTime value \(expected layout 2006-01-02\): =2018-01-02T03:04:05Z`).ExpectError(opt.Set("=2018-01-02T03:04:05Z"))
+}
@@ -0,0 +1,144 @@
+package gocommander
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResponseFilePrefix is the prefix of the argument "@@$FILE" which is expanded to the arguments in the response file FILE.
+const ResponseFilePrefix = "@@"
+
+// ExpandResponseFiles returns the arguments whose response file arguments "@@$FILE" are expanded recursively.
+// Each line of a response file is split into the arguments like a shell: the arguments are separated by whitespaces, quoted by '...' or "...", or escaped by "\", and a line starting with "#" is a comment.
+// The lines can be arbitrarily long.
+// The relative paths of the response files are relative to the current directory.
+// The arguments following the terminator "--" are not expanded.
+//
+// This function returns an error citing the file and the line if a response file cannot be read, has an unterminated quote, or includes itself.
+func ExpandResponseFiles(args []string) ([]string, error) {
+	expanded := make([]string, 0, len(args))
+	terminated := false
+	if err := expandResponseFiles(args, nil, &expanded, &terminated, ""); err != nil {
+		return nil, err
+	}
+	return expanded, nil
+}
+
+// expandResponseFiles appends the expanded args to expanded.
+// stack has the absolute paths of the response files being expanded, and location is the location of args used in the error messages.
+func expandResponseFiles(args []string, stack []string, expanded *[]string, terminated *bool, location string) error {
+	for _, arg := range args {
+		if *terminated || !strings.HasPrefix(arg, ResponseFilePrefix) {
+			*terminated = *terminated || arg == "--"
+			*expanded = append(*expanded, arg)
+			continue
+		}
+		if err := expandResponseFile(arg[len(ResponseFilePrefix):], stack, expanded, terminated); err != nil {
+			if location != "" {
+				return fmt.Errorf("%s: %s", location, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// expandResponseFile appends the expanded arguments in the response file with the given path to expanded.
+func expandResponseFile(path string, stack []string, expanded *[]string, terminated *bool) error {
+	abspath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	for i, p := range stack {
+		if p == abspath {
+			return fmt.Errorf("response file cycle: %s -> %s", strings.Join(stack[i:], " -> "), abspath)
+		}
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	stack = append(stack, abspath)
+	reader := bufio.NewReader(file)
+	for lineno := 1; ; lineno++ {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("%s: %s", path, readErr)
+		}
+		if line == "" && readErr == io.EOF {
+			return nil
+		}
+		location := fmt.Sprintf("%s:%d", path, lineno)
+		args, err := splitResponseLine(strings.TrimSuffix(line, "\n"))
+		if err != nil {
+			return fmt.Errorf("%s: %s", location, err)
+		}
+		if err := expandResponseFiles(args, stack, expanded, terminated, location); err != nil {
+			return err
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// splitResponseLine splits the line of a response file into the arguments.
+//
+// This function returns an error if the line has an unterminated quote or ends with "\".
+func splitResponseLine(line string) ([]string, error) {
+	args := []string{}
+	var arg strings.Builder
+	inArg, quote, escaped := false, rune(0), false
+	for _, r := range line {
+		switch {
+		case escaped:
+			if quote == '"' && r != '"' && r != '\\' {
+				arg.WriteRune('\\')
+			}
+			arg.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\\' && quote != '\'':
+			inArg, escaped = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			inArg, quote = true, r
+		case r == ' ' || r == '\t' || r == '\r':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		case r == '#' && !inArg:
+			return args, nil
+		default:
+			inArg = true
+			arg.WriteRune(r)
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("unterminated escape")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote %c", quote)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,47 @@
+package gocommander
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestSplitResponseLine(t *testing.T) {
+	goassert.New(t, []string{}).EqualWithoutError(splitResponseLine(""))
+	goassert.New(t, []string{}).EqualWithoutError(splitResponseLine("  # comment"))
+	goassert.New(t, []string{"@cmd", "opt=a b", `x"y`, `\@z`, "", "w#v"}).EqualWithoutError(splitResponseLine(`@cmd opt='a b' "x\"y" \\@z '' w#v # comment`))
+	goassert.New(t, []string{`a\b`, "c d"}).EqualWithoutError(splitResponseLine(`"a\b" c\ d`))
+	goassert.New(t, `unterminated quote '`).ExpectError(splitResponseLine(`'a`))
+	goassert.New(t, `unterminated quote "`).ExpectError(splitResponseLine(`"a`))
+	goassert.New(t, `unterminated escape`).ExpectError(splitResponseLine(`a\`))
+}
+
+func TestExpandResponseFiles(t *testing.T) {
+	dir := FilePath(t.TempDir())
+	inner := writeTestFile(t, dir, "inner.txt", "in.csv\n'out file.json'\n")
+	outer := writeTestFile(t, dir, "outer.txt", "# options\n@convert pretty+\n@@"+string(inner)+"\n")
+	goassert.New(t, []string{"@convert", "pretty+", "in.csv", "out file.json", "--", "@@x"}).EqualWithoutError(ExpandResponseFiles([]string{"@@" + string(outer), "--", "@@x"}))
+
+	commander := New(nil)
+	commander.Add("convert", &convertCommand{})
+	goassert.New(t, 5).EqualWithoutError(commander.Parse([]string{"@@" + string(outer), "id"}))
+	goassert.New(t, `[fields:["id"] in:"in.csv" out:"out file.json" pretty:true]`).Equal(commander.Get("convert").OptionsString())
+
+	cycle1 := dir.Join("cycle1.txt")
+	cycle2 := writeTestFile(t, dir, "cycle2.txt", "a\n@@"+string(cycle1)+"\n")
+	writeTestFile(t, dir, "cycle1.txt", "@@"+string(cycle2)+"\n")
+	goassert.New(t, "^"+string(cycle1)+":1: "+string(cycle2)+":2: response file cycle: "+string(cycle1)+" -> "+string(cycle2)+" -> "+string(cycle1)+"$").ExpectError(ExpandResponseFiles([]string{"@@" + string(cycle1)}))
+	broken := writeTestFile(t, dir, "broken.txt", "a\nb 'c\n")
+	goassert.New(t, "^"+string(broken)+":2: unterminated quote '$").ExpectError(commander.Parse([]string{"@@" + string(broken)}))
+	missing := writeTestFile(t, dir, "missing.txt", "@@"+string(dir.Join("unknown.txt")))
+	goassert.New(t, "^"+string(missing)+":1: open .*unknown.txt: no such file or directory$").ExpectError(ExpandResponseFiles([]string{"@@" + string(missing)}))
+	goassert.New(t, "^open unknown.txt: no such file or directory$").ExpectError(ExpandResponseFiles([]string{"@@unknown.txt"}))
+}
+
+func TestExpandResponseFilesLongLine(t *testing.T) {
+	dir := FilePath(t.TempDir())
+	long := strings.Repeat("x", 100*1024)
+	path := writeTestFile(t, dir, "long.txt", "a "+long+" b\nc")
+	goassert.New(t, []string{"a", long, "b", "c"}).EqualWithoutError(ExpandResponseFiles([]string{"@@" + string(path)}))
+}
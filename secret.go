@@ -0,0 +1,59 @@
+package gocommander
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SecretMask is shown instead of the values of secret options.
+const SecretMask = "******"
+
+// Secret returns the OptionAttribute making the option secret.
+// The value of a secret option is masked in Context.OptionsString, Context.OptionsSourceString, Context.Help, Commander.ShowConfig and the error messages.
+// The secret option NAME can be also specified by "NAME-file=$PATH" (the file content without the trailing newlines) or "NAME-env=$VAR" (the environment variable) in the command line instead of the value itself.
+func Secret() OptionAttribute {
+	return func(meta *optionMeta) {
+		meta.secret = true
+	}
+}
+
+// valueString returns the string representation of the value of the option with the given name, which is masked if the option is secret.
+func (ctx *Context) valueString(name string) string {
+	if ctx.metas[name].secret {
+		return SecretMask
+	}
+	return ctx.opts[name].String()
+}
+
+// parseSecret sets the secret option specified by "NAME-file=$PATH" or "NAME-env=$VAR" with the given key and value, and returns NAME.
+// If key is not in such a form, then this function returns the empty string.
+//
+// This function returns an error in reading the secret or setting the option.
+func (ctx *Context) parseSecret(key, value string) (string, error) {
+	for _, suffix := range []string{"-file", "-env"} {
+		name := strings.TrimSuffix(key, suffix)
+		if name == key || ctx.opts[name] == nil || !ctx.metas[name].secret {
+			continue
+		}
+		if !strings.HasPrefix(value, "=") {
+			return name, fmt.Errorf("expected %s=VALUE, but got: %s%s", key, key, value)
+		}
+		source, secret := Source{Kind: SourceCommandLine}, ""
+		if suffix == "-file" {
+			data, err := os.ReadFile(value[len("="):])
+			if err != nil {
+				return name, err
+			}
+			secret = strings.TrimRight(string(data), "\r\n")
+		} else {
+			var err error
+			source.Kind, source.Location = SourceEnv, value[len("="):]
+			if secret, err = Env(source.Location).Unwrap(); err != nil {
+				return name, fmt.Errorf("environment variable $%s is not set", source.Location)
+			}
+		}
+		return name, ctx.metas[name].setValue(ctx.opts[name], secret, source)
+	}
+	return "", nil
+}
@@ -0,0 +1,102 @@
+package gocommander
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+	"github.com/hiro4bbh/go-log"
+)
+
+type secretCommand struct{}
+
+func (cmd *secretCommand) Description() string {
+	return "secret command"
+}
+
+func (cmd *secretCommand) Init(ctx *Context) {
+	ctx.AddOption("token", NewOptionString("default-token"), "API token", Secret())
+	ctx.AddOption("pin", NewOptionInt(0), "PIN", Secret(), Validator(func(opt Option) error {
+		if pin := opt.(*OptionInt).Get(); pin < 1000 {
+			return fmt.Errorf("too short PIN: %d", pin)
+		}
+		return nil
+	}))
+	ctx.AddOption("user", NewOptionString("guest"), "user name")
+}
+
+func (cmd *secretCommand) Run(ctx *Context) error {
+	return nil
+}
+
+func TestSecret(t *testing.T) {
+	var buf bytes.Buffer
+	commander := New(&Settings{
+		Logger:        golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "}),
+		EnvPrefix:     "TEST_",
+		ConfigCommand: true,
+	})
+	ctx := commander.Add("cmd", &secretCommand{})
+	goassert.New(t, `[pin:******(default) token:******(default) user:"guest"(default)]`).Equal(ctx.OptionsSourceString())
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "token=s3cr3t", "pin=1234"}))
+	ctx = commander.Get("cmd")
+	goassert.New(t, "s3cr3t").EqualWithoutError(Lookup[string](ctx, "token"))
+	goassert.New(t, 1234).EqualWithoutError(Lookup[int](ctx, "pin"))
+	goassert.New(t, `[pin:****** token:****** user:"guest"]`).Equal(ctx.OptionsString())
+
+	dir := FilePath(t.TempDir())
+	path := writeTestFile(t, dir, "token", "from-file\r\n")
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "token-file=" + string(path)}))
+	ctx = commander.Get("cmd")
+	goassert.New(t, "from-file").EqualWithoutError(Lookup[string](ctx, "token"))
+	goassert.New(t, Source{Kind: SourceCommandLine}).Equal(ctx.Source("token"))
+	t.Setenv("SECRET_PIN", "5678")
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "pin-env=SECRET_PIN"}))
+	ctx = commander.Get("cmd")
+	goassert.New(t, 5678).EqualWithoutError(Lookup[int](ctx, "pin"))
+	goassert.New(t, Source{SourceEnv, "SECRET_PIN"}).Equal(ctx.Source("pin"))
+
+	goassert.New(t, `@cmd: pin: environment variable \$UNKNOWN_PIN is not set`).ExpectError(commander.Parse([]string{"@cmd", "pin-env=UNKNOWN_PIN"}))
+	goassert.New(t, `@cmd: token: expected token-file=VALUE, but got: token-file`).ExpectError(commander.Parse([]string{"@cmd", "token-file"}))
+	goassert.New(t, `@cmd: token: open .*: no such file or directory`).ExpectError(commander.Parse([]string{"@cmd", "token-file=" + string(dir.Join("unknown"))}))
+	goassert.New(t, `@cmd: unknown option: user-file \(valid options: help, pin, token, user\)`).ExpectError(commander.Parse([]string{"@cmd", "user-file=" + string(path)}))
+	goassert.New(t, `@cmd: pin: illegal OptionInt value: \*\*\*\*\*\*`).ExpectError(commander.Parse([]string{"@cmd", "pin=abcd"}))
+	goassert.New(t, `@cmd: pin: too short PIN: \*\*\*\*\*\*`).ExpectError(commander.Parse([]string{"@cmd", "pin=123"}))
+
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "show", "@cmd", "token=s3cr3t"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "configuration file: none\n@cmd:\n  pin:******\t(default)\n  token:******\t(command line)\n  user:\"guest\"\t(default)\n").Equal(buf.String())
+
+	buf.Reset()
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@cmd", "help"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "An go-commander application\nCopyright 2018- Tatsuhiro Aoshima (hiro4bbh@gmail.com).\n\n@cmd: secret command\noptions:\n  help\tShow this help and exit\n  pin=INT\tPIN (secret, or pin-file=PATH or pin-env=VAR) (env $TEST_CMD_PIN)\n  token=VALUE\tAPI token (secret, or token-file=PATH or token-env=VAR) (env $TEST_CMD_TOKEN)\n  user=VALUE\tuser name (default \"guest\", env $TEST_CMD_USER)\n").Equal(buf.String())
+}
+
+func TestSecretMaskSetString(t *testing.T) {
+	dir := FilePath(t.TempDir())
+	path := writeTestFile(t, dir, "flag", "hunter2\n")
+	config := writeTestFile(t, dir, "config.ini", "[cmd]\nflag = hunter2\n")
+	commander := New(&Settings{EnvPrefix: "TEST_", ConfigCommand: true})
+	commander.Add("cmd", &secretFlagCommand{})
+	goassert.New(t, `^@cmd: flag: illegal OptionBool value: \*\*\*\*\*\*$`).ExpectError(commander.Parse([]string{"@cmd", "flag-file=" + string(path)}))
+	goassert.New(t, `^@cmd: flag: .*config.ini:2: illegal OptionBool value: \*\*\*\*\*\*$`).ExpectError(commander.Parse([]string{"@config", "file=" + string(config), "@cmd"}))
+	t.Setenv("TEST_CMD_FLAG", "hunter2")
+	goassert.New(t, `^@cmd: flag: \$TEST_CMD_FLAG: illegal OptionBool value: \*\*\*\*\*\*$`).ExpectError(commander.Parse([]string{"@cmd"}))
+	goassert.New(t, `^@cmd: flag: illegal OptionBool value: \*\*\*\*\*\*$`).ExpectError(commander.Parse([]string{"@cmd", "flag-env=TEST_CMD_FLAG"}))
+}
+
+type secretFlagCommand struct{}
+
+func (cmd *secretFlagCommand) Description() string {
+	return "secret flag command"
+}
+
+func (cmd *secretFlagCommand) Init(ctx *Context) {
+	ctx.AddOption("flag", NewOptionBool(false), "flag", Secret())
+}
+
+func (cmd *secretFlagCommand) Run(ctx *Context) error {
+	return nil
+}
@@ -0,0 +1,75 @@
+package gocommander
+
+import (
+	"fmt"
+)
+
+// SourceKind is the kind of the source of an option value.
+type SourceKind int
+
+const (
+	// SourceDefault is the default value.
+	SourceDefault SourceKind = iota
+	// SourceConfig is the configuration file.
+	SourceConfig
+	// SourceEnv is the environment variable.
+	SourceEnv
+	// SourceCommandLine is the command line.
+	SourceCommandLine
+)
+
+// String returns the string representation.
+func (kind SourceKind) String() string {
+	switch kind {
+	case SourceDefault:
+		return "default"
+	case SourceConfig:
+		return "config"
+	case SourceEnv:
+		return "env"
+	case SourceCommandLine:
+		return "command line"
+	}
+	return fmt.Sprintf("SourceKind(%d)", int(kind))
+}
+
+// Source is the source of an option value.
+type Source struct {
+	// Kind is the source kind.
+	Kind SourceKind
+	// Location is "$PATH:$LINE" for SourceConfig, the variable name for SourceEnv, otherwise the empty string.
+	Location string
+}
+
+// String returns the string representation.
+func (src Source) String() string {
+	switch src.Kind {
+	case SourceConfig:
+		return fmt.Sprintf("config %s", src.Location)
+	case SourceEnv:
+		return fmt.Sprintf("env $%s", src.Location)
+	}
+	return src.Kind.String()
+}
+
+// Source returns the source of the value of the option with the given name.
+// If the option is set multiple times, then the source is the last one.
+// The source of an unknown option is SourceDefault.
+func (ctx *Context) Source(name string) Source {
+	if meta := ctx.metas[name]; meta != nil {
+		return meta.source
+	}
+	return Source{}
+}
+
+// OptionsSourceString returns the string representation of options with their sources.
+func (ctx *Context) OptionsSourceString() string {
+	str := "["
+	for i, name := range ctx.optionNames() {
+		if i > 0 {
+			str += " "
+		}
+		str += fmt.Sprintf("%s:%s(%s)", name, ctx.valueString(name), ctx.metas[name].source)
+	}
+	return str + "]"
+}
@@ -0,0 +1,53 @@
+package gocommander
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+	"github.com/hiro4bbh/go-log"
+)
+
+func TestSource(t *testing.T) {
+	goassert.New(t, "default").Equal(Source{}.String())
+	goassert.New(t, "config a.ini:3").Equal(Source{SourceConfig, "a.ini:3"}.String())
+	goassert.New(t, "env $X").Equal(Source{SourceEnv, "X"}.String())
+	goassert.New(t, "command line").Equal(Source{Kind: SourceCommandLine}.String())
+	goassert.New(t, "SourceKind(9)").Equal(SourceKind(9).String())
+
+	var buf bytes.Buffer
+	dir := FilePath(t.TempDir())
+	path := writeTestFile(t, dir, "config.ini", "[train]\nthreads = 4\nrate = 0.5\ninclude = a\ninclude = b\n")
+	commander := New(&Settings{
+		Logger:        golog.New(&buf, &golog.Parameters{MinLevel: golog.DEBUG, TimeFormat: " "}),
+		EnvPrefix:     "TEST_",
+		ConfigCommand: true,
+	})
+	cmd := &configTestCommand{}
+	commander.Add("train", cmd)
+	t.Setenv("TEST_TRAIN_RATE", "0.25")
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "@train", "name=x"}))
+	ctx := commander.Get("train")
+	goassert.New(t, Source{}).Equal(ctx.Source("debug"))
+	goassert.New(t, Source{SourceConfig, string(path) + ":2"}).Equal(ctx.Source("threads"))
+	goassert.New(t, Source{SourceConfig, string(path) + ":5"}).Equal(ctx.Source("include"))
+	goassert.New(t, Source{SourceEnv, "TEST_TRAIN_RATE"}).Equal(ctx.Source("rate"))
+	goassert.New(t, Source{Kind: SourceCommandLine}).Equal(ctx.Source("name"))
+	goassert.New(t, Source{}).Equal(ctx.Source("unknown"))
+	goassert.New(t, `[debug:false(default) include:["a" "b"](config `+string(path)+`:5) label:{}(default) name:"x"(command line) rate:0.25(env $TEST_TRAIN_RATE) threads:4(config `+string(path)+`:2)]`).Equal(ctx.OptionsSourceString())
+
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "show", "@train", "name=x"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "configuration file: "+string(path)+"\n@train:\n  debug:false\t(default)\n  include:[\"a\" \"b\"]\t(config "+string(path)+":5)\n  label:{}\t(default)\n  name:\"x\"\t(command line)\n  rate:0.25\t(env $TEST_TRAIN_RATE)\n  threads:4\t(config "+string(path)+":2)\n").Equal(buf.String())
+
+	buf.Reset()
+	path = writeTestFile(t, dir, "profile.ini", "[profiles.dev.train]\nthreads = 2\n")
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "file=" + string(path), "profile=dev", "show"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "configuration file: "+string(path)+" (profile dev)\n").Equal(buf.String())
+
+	buf.Reset()
+	goassert.New(t).SucceedNew(commander.Parse([]string{"@config", "show"}))
+	goassert.New(t).SucceedWithoutError(commander.Run())
+	goassert.New(t, "configuration file: none\n").Equal(buf.String())
+}
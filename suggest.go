@@ -0,0 +1,66 @@
+package gocommander
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxListedNames is the maximum number of the valid names listed in the error messages of unknown names.
+const maxListedNames = 5
+
+// editDistance returns the edit distance between a and b, where an edit is an insertion, a deletion, a substitution or a transposition of adjacent characters.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+// suggest returns the candidates closest to name in order.
+// The candidates farther than a third of the length of name (at least 1) are not suggested.
+func suggest(name string, candidates []string) []string {
+	best, suggestions := max(1, len([]rune(name))/3), []string{}
+	for _, candidate := range candidates {
+		if d := editDistance(name, candidate); d < best {
+			best, suggestions = d, []string{candidate}
+		} else if d == best {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	return suggestions
+}
+
+// unknownSuffix returns the suffix of the error message of the unknown name like " (did you mean X? valid KIND: X, Y)".
+// The suggestions are computed by suggest, and the valid names are listed if there are at most maxListedNames candidates.
+// kind is the plural noun of the candidates.
+func unknownSuffix(name string, candidates []string, kind string) string {
+	parts := []string{}
+	if suggestions := suggest(name, candidates); len(suggestions) > 0 {
+		parts = append(parts, fmt.Sprintf("did you mean %s?", joinOr(suggestions)))
+	}
+	if len(candidates) > 0 && len(candidates) <= maxListedNames {
+		parts = append(parts, fmt.Sprintf("valid %s: %s", kind, strings.Join(candidates, ", ")))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, " ") + ")"
+}
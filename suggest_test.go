@@ -0,0 +1,29 @@
+package gocommander
+
+import (
+	"testing"
+
+	"github.com/hiro4bbh/go-assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	goassert.New(t, 0).Equal(editDistance("", ""))
+	goassert.New(t, 3).Equal(editDistance("", "abc"))
+	goassert.New(t, 1).Equal(editDistance("thread", "threads"))
+	goassert.New(t, 1).Equal(editDistance("theads", "threads"))
+	goassert.New(t, 1).Equal(editDistance("thraeds", "threads"))
+	goassert.New(t, 1).Equal(editDistance("thredas", "threads"))
+	goassert.New(t, 3).Equal(editDistance("kitten", "sitting"))
+	goassert.New(t, 1).Equal(editDistance("日本", "日本語"))
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"threads", "thread-count", "treads", "timeout"}
+	goassert.New(t, []string{"threads", "treads"}).Equal(suggest("tkreads", candidates))
+	goassert.New(t, []string{"threads"}).Equal(suggest("threds", candidates))
+	goassert.New(t, []string{}).Equal(suggest("x", candidates))
+	goassert.New(t, " (did you mean threads? valid options: threads, timeout)").Equal(unknownSuffix("threds", []string{"threads", "timeout"}, "options"))
+	goassert.New(t, " (did you mean threads or treads?)").Equal(unknownSuffix("tkreads", []string{"a", "b", "c", "d", "threads", "treads"}, "options"))
+	goassert.New(t, "").Equal(unknownSuffix("x", []string{"a1", "b1", "c1", "d1", "e1", "f1"}, "options"))
+	goassert.New(t, "").Equal(unknownSuffix("x", nil, "options"))
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 
 	"github.com/hiro4bbh/go-log"
 )
@@ -84,6 +85,15 @@ func (p FilePath) Ext() string {
 	return filepath.Ext(string(p))
 }
 
+// Expand returns the FilePath whose leading "~" is replaced with HomeDir and whose environment variables are expanded by os.ExpandEnv.
+func (p FilePath) Expand() FilePath {
+	str := os.ExpandEnv(string(p))
+	if str == "~" || strings.HasPrefix(str, "~/") {
+		return HomeDir().Join(FilePath(str[len("~"):]))
+	}
+	return FilePath(str)
+}
+
 // Join returns the joined FilePath.
 func (p FilePath) Join(q FilePath) FilePath {
 	return FilePath(filepath.Join(string(p), string(q)))
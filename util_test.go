@@ -24,6 +24,9 @@ func TestFilePath(t *testing.T) {
 	goassert.New(t, FilePath("a/b")).Equal(FilePath("a/b/c").Dir())
 	goassert.New(t, ".txt").Equal(FilePath("a/b/c.txt").Ext())
 	goassert.New(t, FilePath("a/b/c")).Equal(FilePath("a").Join("b/c"))
+	goassert.New(t, HomeDir()).Equal(FilePath("~").Expand())
+	goassert.New(t, HomeDir().Join("a/warn")).Equal(FilePath("~/a/$GOLOG_MINLEVEL").Expand())
+	goassert.New(t, FilePath("a/~/b")).Equal(FilePath("a/~/b").Expand())
 }
 
 func TestEnv(t *testing.T) {